@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/jaby/tabgo/tableau"
+	"github.com/spf13/cobra"
+)
+
+// alertsCmd represents the alerts command
+var alertsCmd = &cobra.Command{
+	Use:   "alerts",
+	Short: "Administers the data driven alerts of a tableau site",
+}
+
+// alertsListCmd represents the alerts list command
+var alertsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the data driven alerts of a tableau site with their owner and view",
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		alerts, err := tabl.ListDataAlerts(tablFilter)
+		if err != nil {
+			log.Fatalf("can not list data alerts, error: %+v", err)
+		}
+		for _, alert := range alerts {
+			fmt.Printf("%s\t%s\t%s\t%s\t%s\n", alert.Id, alert.Subject, alert.Owner.Name, alert.View.Name, alert.Frequency)
+		}
+	},
+}
+
+// alertsShowCmd represents the alerts show command
+var alertsShowCmd = &cobra.Command{
+	Use:   "show <alert id>",
+	Short: "Shows a data driven alert with its owner and recipients",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		alert, err := tabl.QueryDataAlert(args[0])
+		if err != nil {
+			log.Fatalf("can not query data alert, error: %+v", err)
+		}
+		fmt.Printf("id:\t%s\n", alert.Id)
+		fmt.Printf("subject:\t%s\n", alert.Subject)
+		fmt.Printf("owner:\t%s\n", alert.Owner.Name)
+		fmt.Printf("view:\t%s (%s)\n", alert.View.Name, alert.View.Id)
+		fmt.Printf("frequency:\t%s\n", alert.Frequency)
+		fmt.Printf("condition:\t%s %s\n", alert.AlertCondition, alert.AlertThreshold)
+		for _, recipient := range alert.Recipients.Recipient {
+			fmt.Printf("recipient:\t%s\tlast sent %s\n", recipient.Name, formatJobTime(recipient.LastSent))
+		}
+	},
+}
+
+// alertsAddCmd represents the alerts add command
+var alertsAddCmd = &cobra.Command{
+	Use:   "add <alert id> <user>...",
+	Short: "Adds users to the recipients of a data driven alert",
+	Args:  cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		for _, name := range args[1:] {
+			user, err := tabl.GetUserByName(name)
+			if err != nil {
+				log.Fatalf("can not find user, error: %+v", err)
+			}
+			if err = tabl.AddDataAlertRecipient(args[0], string(user.Id)); err != nil {
+				log.Fatalf("can not add recipient, error: %+v", err)
+			}
+		}
+	},
+}
+
+// alertsRemoveCmd represents the alerts remove command
+var alertsRemoveCmd = &cobra.Command{
+	Use:   "remove <alert id> <user>...",
+	Short: "Removes users from the recipients of a data driven alert",
+	Args:  cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		for _, name := range args[1:] {
+			user, err := tabl.GetUserByName(name)
+			if err != nil {
+				log.Fatalf("can not find user, error: %+v", err)
+			}
+			if err = tabl.RemoveDataAlertRecipient(args[0], string(user.Id)); err != nil {
+				log.Fatalf("can not remove recipient, error: %+v", err)
+			}
+		}
+	},
+}
+
+// alertsDeleteCmd represents the alerts delete command
+var alertsDeleteCmd = &cobra.Command{
+	Use:   "delete <alert id>...",
+	Short: "Deletes data driven alerts",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		for _, alertID := range args {
+			if err := tabl.DeleteDataAlert(alertID); err != nil {
+				log.Fatalf("can not delete data alert, error: %+v", err)
+			}
+		}
+	},
+}
+
+// alertsPruneCmd represents the alerts prune command
+var alertsPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Deletes the data driven alerts of views that no longer exist, e.g. after a republish removed them",
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		orphans, err := tabl.ListOrphanedDataAlerts()
+		if err != nil {
+			log.Fatalf("can not list orphaned data alerts, error: %+v", err)
+		}
+		if len(orphans) == 0 {
+			fmt.Println("no data alerts of removed views")
+			return
+		}
+		fmt.Println("plan:")
+		for _, alert := range orphans {
+			fmt.Println(describeOrphan(alert))
+		}
+		if tablDryRun {
+			return
+		}
+
+		for _, alert := range orphans {
+			if err = tabl.DeleteDataAlert(string(alert.Id)); err != nil {
+				log.Fatalf("can not delete data alert, error: %+v", err)
+			}
+		}
+		fmt.Printf("deleted %d data alerts\n", len(orphans))
+	},
+}
+
+func describeOrphan(alert tableau.DataAlertType) string {
+	return fmt.Sprintf("- %s (%s, owner %s, view %s)", alert.Subject, alert.Id, alert.Owner.Name, alert.View.Id)
+}
+
+func init() {
+	rootCmd.AddCommand(alertsCmd)
+	addSigninFlags(alertsCmd)
+
+	alertsCmd.AddCommand(alertsListCmd)
+	alertsCmd.AddCommand(alertsShowCmd)
+	alertsCmd.AddCommand(alertsAddCmd)
+	alertsCmd.AddCommand(alertsRemoveCmd)
+	alertsCmd.AddCommand(alertsDeleteCmd)
+	alertsCmd.AddCommand(alertsPruneCmd)
+
+	alertsListCmd.Flags().StringVar(&tablFilter, "filter", "", "tableau filter expression, e.g. ownerName:eq:jdoe")
+	alertsPruneCmd.Flags().BoolVar(&tablDryRun, "dry-run", false, "only print the plan, do not change anything")
+}
@@ -0,0 +1,322 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/jaby/tabgo/tableau"
+	"github.com/spf13/cobra"
+)
+
+var tablAssetDescription string
+var tablAssetCertified bool
+var tablAssetCertificationNote string
+var tablAssetContact string
+var tablWarningKind string
+var tablWarningContentID string
+var tablWarning tableau.DataQualityWarningType
+
+// catalogCmd represents the catalog command
+var catalogCmd = &cobra.Command{
+	Use:   "catalog",
+	Short: "Administers the database and table assets of a tableau site and their data quality warnings",
+}
+
+// catalogDatabasesCmd represents the catalog databases command
+var catalogDatabasesCmd = &cobra.Command{
+	Use:   "databases",
+	Short: "Lists, shows and updates database assets",
+}
+
+// catalogDatabasesListCmd represents the catalog databases list command
+var catalogDatabasesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the database assets of a tableau site",
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		databases, err := tabl.ListDatabases(tablFilter)
+		if err != nil {
+			log.Fatalf("can not list databases, error: %+v", err)
+		}
+		for _, database := range databases {
+			fmt.Printf("%s\t%s\t%s\t%s\tcertified=%t\n", database.Id, database.Name, database.ConnectionType, database.HostName, database.IsCertified)
+		}
+	},
+}
+
+// catalogDatabasesShowCmd represents the catalog databases show command
+var catalogDatabasesShowCmd = &cobra.Command{
+	Use:   "show <database id>",
+	Short: "Shows a database asset with its description, certification and contact",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		database, err := tabl.QueryDatabase(args[0])
+		if err != nil {
+			log.Fatalf("can not query database, error: %+v", err)
+		}
+		fmt.Printf("id:\t%s\n", database.Id)
+		fmt.Printf("name:\t%s\n", database.Name)
+		fmt.Printf("connection:\t%s %s\n", database.ConnectionType, database.HostName)
+		printAsset(database.Description, database.IsCertified, database.CertificationNote, database.Certifier, database.Contact)
+	},
+}
+
+// catalogDatabasesUpdateCmd represents the catalog databases update command
+var catalogDatabasesUpdateCmd = &cobra.Command{
+	Use:   "update <database id>",
+	Short: "Changes the description, certification and/or contact of a database asset",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		if _, err := tabl.UpdateDatabase(args[0], assetUpdate(cmd, tabl)); err != nil {
+			log.Fatalf("can not update database, error: %+v", err)
+		}
+	},
+}
+
+// catalogTablesCmd represents the catalog tables command
+var catalogTablesCmd = &cobra.Command{
+	Use:   "tables",
+	Short: "Lists, shows and updates table assets",
+}
+
+// catalogTablesListCmd represents the catalog tables list command
+var catalogTablesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the table assets of a tableau site",
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		tables, err := tabl.ListTables(tablFilter)
+		if err != nil {
+			log.Fatalf("can not list tables, error: %+v", err)
+		}
+		for _, table := range tables {
+			fmt.Printf("%s\t%s\t%s\tcertified=%t\n", table.Id, table.Schema, table.Name, table.IsCertified)
+		}
+	},
+}
+
+// catalogTablesShowCmd represents the catalog tables show command
+var catalogTablesShowCmd = &cobra.Command{
+	Use:   "show <table id>",
+	Short: "Shows a table asset with its description, certification, contact and columns",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		table, err := tabl.QueryTable(args[0])
+		if err != nil {
+			log.Fatalf("can not query table, error: %+v", err)
+		}
+		columns, err := tabl.ListTableColumns(args[0])
+		if err != nil {
+			log.Fatalf("can not list columns, error: %+v", err)
+		}
+		fmt.Printf("id:\t%s\n", table.Id)
+		fmt.Printf("name:\t%s.%s\n", table.Schema, table.Name)
+		printAsset(table.Description, table.IsCertified, table.CertificationNote, table.Certifier, table.Contact)
+		for _, column := range columns {
+			fmt.Printf("column:\t%s\t%s\t%s\n", column.Name, column.RemoteType, column.Description)
+		}
+	},
+}
+
+// catalogTablesUpdateCmd represents the catalog tables update command
+var catalogTablesUpdateCmd = &cobra.Command{
+	Use:   "update <table id>",
+	Short: "Changes the description, certification and/or contact of a table asset",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		if _, err := tabl.UpdateTable(args[0], assetUpdate(cmd, tabl)); err != nil {
+			log.Fatalf("can not update table, error: %+v", err)
+		}
+	},
+}
+
+// catalogWarningsCmd represents the catalog warnings command
+var catalogWarningsCmd = &cobra.Command{
+	Use:   "warnings",
+	Short: "Manages the data quality warnings of databases, tables, datasources and flows",
+}
+
+// catalogWarningsListCmd represents the catalog warnings list command
+var catalogWarningsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the data quality warnings of a database, table, datasource or flow",
+	Run: func(cmd *cobra.Command, args []string) {
+		kind := warningKind()
+
+		tabl := signin()
+		defer signout(tabl)
+
+		warnings, err := tabl.ListDataQualityWarnings(kind, tablWarningContentID)
+		if err != nil {
+			log.Fatalf("can not list data quality warnings, error: %+v", err)
+		}
+		for _, warning := range warnings {
+			fmt.Printf("%s\t%s\tactive=%t\t%s\t%s\n", warning.Id, warning.Type, warning.IsActive, warning.Owner.Name, warning.Message)
+		}
+	},
+}
+
+// catalogWarningsCreateCmd represents the catalog warnings create command
+var catalogWarningsCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Adds a data quality warning to a database, table, datasource or flow, e.g. when an upstream source is deprecated",
+	Run: func(cmd *cobra.Command, args []string) {
+		kind := warningKind()
+
+		tabl := signin()
+		defer signout(tabl)
+
+		warning, err := tabl.CreateDataQualityWarning(kind, tablWarningContentID, tablWarning)
+		if err != nil {
+			log.Fatalf("can not create data quality warning, error: %+v", err)
+		}
+		fmt.Printf("created data quality warning %s\n", warning.Id)
+	},
+}
+
+// catalogWarningsUpdateCmd represents the catalog warnings update command
+var catalogWarningsUpdateCmd = &cobra.Command{
+	Use:   "update <warning id>",
+	Short: "Changes the type, message and/or activity of a data quality warning",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		warning, err := tabl.QueryDataQualityWarning(args[0])
+		if err != nil {
+			log.Fatalf("can not find data quality warning, error: %+v", err)
+		}
+		// only the given flags change the warning
+		if cmd.Flags().Changed("type") {
+			warning.Type = tablWarning.Type
+		}
+		if cmd.Flags().Changed("message") {
+			warning.Message = tablWarning.Message
+		}
+		if cmd.Flags().Changed("active") {
+			warning.IsActive = tablWarning.IsActive
+		}
+		if _, err = tabl.UpdateDataQualityWarning(args[0], warning); err != nil {
+			log.Fatalf("can not update data quality warning, error: %+v", err)
+		}
+	},
+}
+
+// catalogWarningsDeleteCmd represents the catalog warnings delete command
+var catalogWarningsDeleteCmd = &cobra.Command{
+	Use:   "delete <warning id>...",
+	Short: "Deletes data quality warnings",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		for _, warningID := range args {
+			if err := tabl.DeleteDataQualityWarning(warningID); err != nil {
+				log.Fatalf("can not delete data quality warning, error: %+v", err)
+			}
+		}
+	},
+}
+
+func printAsset(description string, certified bool, certificationNote string, certifier, contact tableau.UserType) {
+	fmt.Printf("description:\t%s\n", description)
+	if certified {
+		fmt.Printf("certified:\tby %s: %s\n", certifier.Name, certificationNote)
+	} else {
+		fmt.Printf("certified:\tno\n")
+	}
+	fmt.Printf("contact:\t%s\n", contact.Name)
+}
+
+// assetUpdate returns the update of the asset flags given on the command line
+func assetUpdate(cmd *cobra.Command, tabl *tableau.TabGo) tableau.AssetUpdate {
+	update := tableau.AssetUpdate{}
+	if cmd.Flags().Changed("description") {
+		update.Description = &tablAssetDescription
+	}
+	if cmd.Flags().Changed("certified") {
+		update.IsCertified = &tablAssetCertified
+	}
+	if cmd.Flags().Changed("certificationNote") {
+		update.CertificationNote = &tablAssetCertificationNote
+	}
+	if tablAssetContact != "" {
+		contact, err := tabl.GetUserByName(tablAssetContact)
+		if err != nil {
+			log.Fatalf("can not find contact, error: %+v", err)
+		}
+		update.ContactID = string(contact.Id)
+	}
+	return update
+}
+
+// warningKind returns the kind of the content of the data quality warning flags
+func warningKind() tableau.ContentKind {
+	for _, kind := range []tableau.ContentKind{tableau.KindDatabase, tableau.KindTable, tableau.KindDatasource, tableau.KindFlow} {
+		if strings.EqualFold(tablWarningKind, string(kind)) || strings.EqualFold(tablWarningKind+"s", string(kind)) {
+			return kind
+		}
+	}
+	log.Fatalf("unknown content kind '%s', expecting database, table, datasource or flow", tablWarningKind)
+	return ""
+}
+
+func init() {
+	rootCmd.AddCommand(catalogCmd)
+	addSigninFlags(catalogCmd)
+
+	catalogCmd.AddCommand(catalogDatabasesCmd)
+	catalogDatabasesCmd.AddCommand(catalogDatabasesListCmd)
+	catalogDatabasesCmd.AddCommand(catalogDatabasesShowCmd)
+	catalogDatabasesCmd.AddCommand(catalogDatabasesUpdateCmd)
+	catalogCmd.AddCommand(catalogTablesCmd)
+	catalogTablesCmd.AddCommand(catalogTablesListCmd)
+	catalogTablesCmd.AddCommand(catalogTablesShowCmd)
+	catalogTablesCmd.AddCommand(catalogTablesUpdateCmd)
+	catalogCmd.AddCommand(catalogWarningsCmd)
+	catalogWarningsCmd.AddCommand(catalogWarningsListCmd)
+	catalogWarningsCmd.AddCommand(catalogWarningsCreateCmd)
+	catalogWarningsCmd.AddCommand(catalogWarningsUpdateCmd)
+	catalogWarningsCmd.AddCommand(catalogWarningsDeleteCmd)
+
+	for _, cmd := range []*cobra.Command{catalogDatabasesListCmd, catalogTablesListCmd} {
+		cmd.Flags().StringVar(&tablFilter, "filter", "", "tableau filter expression, e.g. name:eq:orders")
+	}
+	for _, cmd := range []*cobra.Command{catalogDatabasesUpdateCmd, catalogTablesUpdateCmd} {
+		cmd.Flags().StringVar(&tablAssetDescription, "description", "", "description of the asset")
+		cmd.Flags().BoolVar(&tablAssetCertified, "certified", false, "certify the asset, --certified=false removes the certification")
+		cmd.Flags().StringVar(&tablAssetCertificationNote, "certificationNote", "", "note explaining the certification")
+		cmd.Flags().StringVar(&tablAssetContact, "contact", "", "name of the user to contact about the asset")
+	}
+	for _, cmd := range []*cobra.Command{catalogWarningsListCmd, catalogWarningsCreateCmd} {
+		cmd.Flags().StringVar(&tablWarningKind, "kind", "", "kind of the content: database, table, datasource or flow")
+		cmd.MarkFlagRequired("kind")
+		cmd.Flags().StringVar(&tablWarningContentID, "id", "", "id of the content")
+		cmd.MarkFlagRequired("id")
+	}
+	for _, cmd := range []*cobra.Command{catalogWarningsCreateCmd, catalogWarningsUpdateCmd} {
+		cmd.Flags().StringVar(&tablWarning.Type, "type", tableau.WarningDeprecated,
+			"type of the warning: Deprecated, Warning, Stale data, Under maintenance or Sensitive data")
+		cmd.Flags().StringVar(&tablWarning.Message, "message", "", "message of the warning")
+		cmd.Flags().BoolVar(&tablWarning.IsActive, "active", true, "show the warning")
+	}
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+)
+
+var tablCertificationNote string
+var tablClearCertification bool
+
+// certifyCmd represents the certify command
+var certifyCmd = &cobra.Command{
+	Use:   "certify <datasource path>...",
+	Short: "Certifies published datasources with a note, or clears their certification",
+	Long: `Certifies published datasources with a note, or clears their certification (--clear).
+A datasource path is its project path followed by its name, e.g. Finance/Sales DWH.
+To keep a datasource certified after a republish, add a certification to its document config.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if !tablClearCertification && tablCertificationNote == "" {
+			log.Fatalf("expecting either --note or --clear")
+		}
+
+		tabl := signin()
+		defer signout(tabl)
+
+		for _, path := range args {
+			datasource, err := tabl.FindDatasource(path)
+			if err != nil {
+				log.Fatalf("can not find datasource, error: %+v", err)
+			}
+			if err = tabl.UpdateDatasourceCertification(string(datasource.Id), !tablClearCertification, tablCertificationNote); err != nil {
+				log.Fatalf("can not update certification, error: %+v", err)
+			}
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(certifyCmd)
+	addSigninFlags(certifyCmd)
+
+	certifyCmd.Flags().StringVar(&tablCertificationNote, "note", "", "note explaining the certification")
+	certifyCmd.Flags().BoolVar(&tablClearCertification, "clear", false, "clear the certification and its note")
+}
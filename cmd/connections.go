@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/jaby/tabgo/tableau"
+	"github.com/spf13/cobra"
+)
+
+var tablConnectionServerAddress string
+var tablConnectionServerPort int
+var tablConnectionUserName string
+var tablConnectionPassword string
+var tablConnectionEmbedPassword bool
+var tablAllConnections bool
+
+// connectionsCmd represents the connections command
+var connectionsCmd = &cobra.Command{
+	Use:   "connections",
+	Short: "Lists and updates the connections of the datasources embedded in a workbook",
+}
+
+// connectionsListCmd represents the connections list command
+var connectionsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the connections of a workbook",
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		workbook, err := tabl.FindWorkbook(tablWorkbookPath)
+		if err != nil {
+			log.Fatalf("can not find workbook, error: %+v", err)
+		}
+		connections, err := tabl.ListWorkbookConnections(string(workbook.Id))
+		if err != nil {
+			log.Fatalf("can not list connections, error: %+v", err)
+		}
+		for _, connection := range connections {
+			fmt.Printf("%s\t%s\t%s:%d\t%s\t%s\tembedPassword=%t\n", connection.Id, connection.Type, connection.ServerAddress, connection.ServerPort,
+				connection.UserName, connection.Datasource.Name, connection.EmbedPassword)
+		}
+	},
+}
+
+// connectionsUpdateCmd represents the connections update command
+var connectionsUpdateCmd = &cobra.Command{
+	Use:   "update [<connection id>...]",
+	Short: "Changes the server address, port, credentials and/or embed flag of connections of a workbook",
+	Long: `Changes the server address, port, credentials and/or embed flag of connections of a workbook,
+e.g. to rotate the credentials of its embedded datasources without republishing it.
+Only the given flags change the connections, --all updates every connection of the workbook
+except its connections to published datasources, update those datasources instead.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if (len(args) == 0) == !tablAllConnections {
+			log.Fatalf("expecting either connection ids or --all")
+		}
+		update := tableau.ConnectionUpdate{}
+		if cmd.Flags().Changed("serverAddress") {
+			update.ServerAddress = &tablConnectionServerAddress
+		}
+		if cmd.Flags().Changed("serverPort") {
+			update.ServerPort = &tablConnectionServerPort
+		}
+		if cmd.Flags().Changed("connectionUserName") {
+			update.UserName = &tablConnectionUserName
+		}
+		if cmd.Flags().Changed("connectionPassword") {
+			update.Password = &tablConnectionPassword
+		}
+		if cmd.Flags().Changed("embedPassword") {
+			update.EmbedPassword = &tablConnectionEmbedPassword
+		}
+		if update == (tableau.ConnectionUpdate{}) {
+			log.Fatalf("nothing to update")
+		}
+
+		tabl := signin()
+		defer signout(tabl)
+
+		workbook, err := tabl.FindWorkbook(tablWorkbookPath)
+		if err != nil {
+			log.Fatalf("can not find workbook, error: %+v", err)
+		}
+		connectionIDs := args
+		if tablAllConnections {
+			connections, err := tabl.ListWorkbookConnections(string(workbook.Id))
+			if err != nil {
+				log.Fatalf("can not list connections, error: %+v", err)
+			}
+			for _, connection := range connections {
+				if tableau.IsPublishedDatasourceConnection(connection) {
+					fmt.Printf("skipping connection %s to published datasource '%s'\n", connection.Id, connection.Datasource.Name)
+					continue
+				}
+				connectionIDs = append(connectionIDs, string(connection.Id))
+			}
+		}
+		for _, connectionID := range connectionIDs {
+			if _, err = tabl.UpdateWorkbookConnection(string(workbook.Id), connectionID, update); err != nil {
+				log.Fatalf("can not update connection, error: %+v", err)
+			}
+		}
+		fmt.Printf("updated %d connections\n", len(connectionIDs))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(connectionsCmd)
+	addSigninFlags(connectionsCmd)
+	connectionsCmd.PersistentFlags().StringVar(&tablWorkbookPath, "workbook", "", "path of a workbook: its project path followed by its name, e.g. Finance/Reports/Revenue")
+	connectionsCmd.MarkPersistentFlagRequired("workbook")
+
+	connectionsCmd.AddCommand(connectionsListCmd)
+	connectionsCmd.AddCommand(connectionsUpdateCmd)
+
+	connectionsUpdateCmd.Flags().StringVar(&tablConnectionServerAddress, "serverAddress", "", "server address of the connections")
+	connectionsUpdateCmd.Flags().IntVar(&tablConnectionServerPort, "serverPort", 0, "server port of the connections")
+	connectionsUpdateCmd.Flags().StringVar(&tablConnectionUserName, "connectionUserName", "", "user name of the connections")
+	connectionsUpdateCmd.Flags().StringVar(&tablConnectionPassword, "connectionPassword", "", "password of the connections, not to be confused with the tableau --password")
+	connectionsUpdateCmd.Flags().BoolVar(&tablConnectionEmbedPassword, "embedPassword", true, "embed the password in the connections")
+	connectionsUpdateCmd.Flags().BoolVar(&tablAllConnections, "all", false, "update all connections of the workbook, except those to published datasources")
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/jaby/tabgo/tableau"
+	"github.com/spf13/cobra"
+)
+
+var tablExportDir string
+var tablExportViews []string
+var tablExportFormats []string
+var tablExportWorkbookPDF bool
+var tablExportOptions tableau.ExportOptions
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Exports the views of a workbook as png image, pdf and/or csv data into a directory",
+	Long: `Exports the views of a workbook as png image, pdf and/or csv data into a directory,
+the files are named after the views, e.g. Overview.png. With --workbookPdf all sheets of the workbook
+are exported into a single pdf named after the workbook.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		formats := []tableau.ExportFormat{}
+		for _, format := range tablExportFormats {
+			switch tableau.ExportFormat(format) {
+			case tableau.ExportPNG, tableau.ExportPDF, tableau.ExportCSV:
+				formats = append(formats, tableau.ExportFormat(format))
+			default:
+				log.Fatalf("invalid format '%s', expecting png, pdf or csv", format)
+			}
+		}
+		if err := os.MkdirAll(tablExportDir, 0755); err != nil {
+			log.Fatalf("can not create %s, error: %+v", tablExportDir, err)
+		}
+
+		tabl := signin()
+		defer signout(tabl)
+
+		workbook, err := tabl.FindWorkbook(tablWorkbookPath)
+		if err != nil {
+			log.Fatalf("can not find workbook, error: %+v", err)
+		}
+		views, err := tabl.ListWorkbookViews(string(workbook.Id))
+		if err != nil {
+			log.Fatalf("can not list views, error: %+v", err)
+		}
+		views = selectViews(views, tablExportViews)
+
+		for _, view := range views {
+			for _, format := range formats {
+				content, err := tabl.ExportView(string(view.Id), format, tablExportOptions)
+				if err != nil {
+					log.Fatalf("can not export view '%s', error: %+v", view.Name, err)
+				}
+				path, err := tableau.WriteExport(content, view.Name, format, tablExportDir)
+				if err != nil {
+					log.Fatalf("can not export view '%s', error: %+v", view.Name, err)
+				}
+				fmt.Println(path)
+			}
+		}
+
+		if tablExportWorkbookPDF {
+			content, err := tabl.ExportWorkbookPDF(string(workbook.Id), tablExportOptions)
+			if err != nil {
+				log.Fatalf("can not export workbook, error: %+v", err)
+			}
+			path, err := tableau.WriteExport(content, workbook.Name, tableau.ExportPDF, tablExportDir)
+			if err != nil {
+				log.Fatalf("can not export workbook, error: %+v", err)
+			}
+			fmt.Println(path)
+		}
+	},
+}
+
+// selectViews returns the views with the given names, or all views when no names are given,
+// exits when a name is not found
+func selectViews(views []tableau.ViewType, names []string) []tableau.ViewType {
+	if len(names) == 0 {
+		return views
+	}
+	selected := []tableau.ViewType{}
+	for _, name := range names {
+		found := false
+		for _, view := range views {
+			if view.Name == name {
+				selected = append(selected, view)
+				found = true
+			}
+		}
+		if !found {
+			log.Fatalf("no view '%s' found in workbook '%s'", name, tablWorkbookPath)
+		}
+	}
+	return selected
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+	addSigninFlags(exportCmd)
+
+	exportCmd.Flags().StringVar(&tablWorkbookPath, "workbook", "", "path of the workbook: its project path followed by its name, e.g. Finance/Reports/Revenue")
+	exportCmd.MarkFlagRequired("workbook")
+	exportCmd.Flags().StringVarP(&tablExportDir, "dir", "o", ".", "directory to write the exported files to, created when missing")
+	exportCmd.Flags().StringSliceVar(&tablExportViews, "views", nil, "names of the views to export, default all views of the workbook")
+	exportCmd.Flags().StringSliceVar(&tablExportFormats, "format", []string{"png"}, "formats to export every view to: png, pdf and/or csv")
+	exportCmd.Flags().BoolVar(&tablExportWorkbookPDF, "workbookPdf", false, "also export the whole workbook into a single pdf")
+	exportCmd.Flags().StringToStringVar(&tablExportOptions.Filters, "viewFilter", nil, "view filters, e.g. Region=West or \"Region=West,East\"")
+	exportCmd.Flags().IntVar(&tablExportOptions.MaxAge, "maxAge", 0, "maximum age in minutes of a cached rendering, 0 leaves it to tableau")
+	exportCmd.Flags().BoolVar(&tablExportOptions.HighResolution, "highResolution", false, "export png images in high resolution")
+	exportCmd.Flags().StringVar(&tablExportOptions.PageType, "pageType", "", "page type of pdfs, e.g. A4, A3, Letter or Legal")
+	exportCmd.Flags().StringVar(&tablExportOptions.Orientation, "orientation", "", "page orientation of pdfs: Portrait or Landscape")
+}
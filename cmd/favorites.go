@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/jaby/tabgo/tableau"
+	"github.com/spf13/cobra"
+)
+
+var tablFavoriteUsers []string
+var tablFavoriteGroups []string
+var tablFavoriteProject string
+var tablFavoriteLabel string
+
+// favoritesCmd represents the favorites command
+var favoritesCmd = &cobra.Command{
+	Use:   "favorites",
+	Short: "Manages the favorites of the users of a tableau site",
+}
+
+// favoritesListCmd represents the favorites list command
+var favoritesListCmd = &cobra.Command{
+	Use:   "list <user>",
+	Short: "Lists the favorites of a user",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		user, err := tabl.GetUserByName(args[0])
+		if err != nil {
+			log.Fatalf("can not find user, error: %+v", err)
+		}
+		favorites, err := tabl.ListFavorites(string(user.Id))
+		if err != nil {
+			log.Fatalf("can not list favorites, error: %+v", err)
+		}
+		for _, favorite := range favorites {
+			kind, id := tableau.FavoriteContent(favorite)
+			if kind == "" {
+				kind = "unknown"
+			}
+			fmt.Printf("%s\t%s\t%s\n", kind, id, favorite.Label)
+		}
+	},
+}
+
+// favoritesAddCmd represents the favorites add command
+var favoritesAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Adds a view, workbook, datasource or project to the favorites of users and/or the members of groups",
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		kind, id, name := favoriteContentFlags(tabl)
+		label := tablFavoriteLabel
+		if label == "" {
+			label = name
+		}
+		users := favoriteUsers(tabl)
+		if len(users) == 0 {
+			log.Fatalf("expecting --user and/or --group")
+		}
+
+		changes, err := tabl.PlanFavorites(users, kind, id, label)
+		if err != nil {
+			log.Fatalf("can not plan favorites, error: %+v", err)
+		}
+		applyFavoriteChanges(tabl, changes, "favorites are up to date")
+	},
+}
+
+// favoritesPruneCmd represents the favorites prune command
+var favoritesPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Removes the favorites pointing at content that no longer exists, of the given users and groups or of all users",
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		users := favoriteUsers(tabl)
+		if len(users) == 0 {
+			var err error
+			if users, err = tabl.ListUsers(""); err != nil {
+				log.Fatalf("can not list users, error: %+v", err)
+			}
+		}
+
+		changes, err := tabl.PlanFavoritePrune(users)
+		if err != nil {
+			log.Fatalf("can not plan favorite prune, error: %+v", err)
+		}
+		applyFavoriteChanges(tabl, changes, "no favorites of deleted content")
+	},
+}
+
+// applyFavoriteChanges prints the plan and, unless it is a dry run, applies it
+func applyFavoriteChanges(tabl *tableau.TabGo, changes []tableau.FavoriteChange, upToDate string) {
+	if len(changes) == 0 {
+		fmt.Println(upToDate)
+		return
+	}
+	fmt.Println("plan:")
+	for _, change := range changes {
+		fmt.Println(change)
+	}
+	if tablDryRun {
+		return
+	}
+
+	if err := tabl.ApplyFavoriteChanges(changes); err != nil {
+		log.Fatalf("can not apply favorites, error: %+v", err)
+	}
+	fmt.Printf("applied %d changes\n", len(changes))
+}
+
+// favoriteUsers returns the users of the user flags and the members of the groups of the group flags, once each
+func favoriteUsers(tabl *tableau.TabGo) []tableau.UserType {
+	users := []tableau.UserType{}
+	seen := make(map[tableau.ResourceIdType]bool)
+	add := func(user tableau.UserType) {
+		if !seen[user.Id] {
+			seen[user.Id] = true
+			users = append(users, user)
+		}
+	}
+	for _, name := range tablFavoriteUsers {
+		user, err := tabl.GetUserByName(name)
+		if err != nil {
+			log.Fatalf("can not find user, error: %+v", err)
+		}
+		add(user)
+	}
+	for _, name := range tablFavoriteGroups {
+		group, err := tabl.GetGroupByName(name)
+		if err != nil {
+			log.Fatalf("can not find group, error: %+v", err)
+		}
+		members, err := tabl.ListGroupUsers(string(group.Id))
+		if err != nil {
+			log.Fatalf("can not list group members, error: %+v", err)
+		}
+		for _, member := range members {
+			add(member)
+		}
+	}
+	return users
+}
+
+// favoriteContentFlags returns the kind, id and name of the content of the view/workbook/datasource/project flags,
+// exits when not exactly one of them is set or the content does not exist
+func favoriteContentFlags(tabl *tableau.TabGo) (tableau.ContentKind, tableau.ResourceIdType, string) {
+	set := 0
+	for _, path := range []string{tablViewPath, tablWorkbookPath, tablDatasourcePath, tablFavoriteProject} {
+		if path != "" {
+			set++
+		}
+	}
+	if set != 1 {
+		log.Fatalf("expecting one of --view, --workbook, --datasource or --project")
+	}
+
+	switch {
+	case tablViewPath != "":
+		view, err := tabl.FindView(tablViewPath)
+		if err != nil {
+			log.Fatalf("can not find view, error: %+v", err)
+		}
+		return tableau.KindView, view.Id, view.Name
+	case tablWorkbookPath != "":
+		workbook, err := tabl.FindWorkbook(tablWorkbookPath)
+		if err != nil {
+			log.Fatalf("can not find workbook, error: %+v", err)
+		}
+		return tableau.KindWorkbook, workbook.Id, workbook.Name
+	case tablDatasourcePath != "":
+		datasource, err := tabl.FindDatasource(tablDatasourcePath)
+		if err != nil {
+			log.Fatalf("can not find datasource, error: %+v", err)
+		}
+		return tableau.KindDatasource, datasource.Id, datasource.Name
+	}
+	project, err := tabl.FindProject(tablFavoriteProject)
+	if err != nil {
+		log.Fatalf("can not find project, error: %+v", err)
+	}
+	if project == nil {
+		log.Fatalf("project '%s' does not exist", tablFavoriteProject)
+	}
+	return tableau.KindProject, project.Project.Id, project.Project.Name
+}
+
+func init() {
+	rootCmd.AddCommand(favoritesCmd)
+	addSigninFlags(favoritesCmd)
+
+	favoritesCmd.AddCommand(favoritesListCmd)
+	favoritesCmd.AddCommand(favoritesAddCmd)
+	favoritesCmd.AddCommand(favoritesPruneCmd)
+
+	favoritesAddCmd.Flags().StringVar(&tablViewPath, "view", "", "path of a view: the path of its workbook followed by its name, e.g. Finance/Reports/Revenue/Overview")
+	favoritesAddCmd.Flags().StringVar(&tablWorkbookPath, "workbook", "", "path of a workbook: its project path followed by its name, e.g. Finance/Reports/Revenue")
+	favoritesAddCmd.Flags().StringVar(&tablDatasourcePath, "datasource", "", "path of a datasource: its project path followed by its name")
+	favoritesAddCmd.Flags().StringVar(&tablFavoriteProject, "project", "", "path of a project, e.g. Finance/Reports")
+	favoritesAddCmd.Flags().StringVar(&tablFavoriteLabel, "label", "", "label of the favorite, default the name of the content")
+	for _, cmd := range []*cobra.Command{favoritesAddCmd, favoritesPruneCmd} {
+		cmd.Flags().StringSliceVar(&tablFavoriteUsers, "user", nil, "names of users")
+		cmd.Flags().StringSliceVar(&tablFavoriteGroups, "group", nil, "names of groups whose members are included")
+		cmd.Flags().BoolVar(&tablDryRun, "dry-run", false, "only print the plan, do not change anything")
+	}
+}
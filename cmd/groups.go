@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/jaby/tabgo/tableau"
+	"github.com/spf13/cobra"
+)
+
+var tablGroupsFile string
+var tablMinimumSiteRole string
+var tablResync bool
+
+// groupsCmd represents the groups command
+var groupsCmd = &cobra.Command{
+	Use:   "groups",
+	Short: "Manages the groups of a tableau site",
+}
+
+// groupsListCmd represents the groups list command
+var groupsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the groups of a tableau site",
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		groups, err := tabl.ListGroups(tablFilter)
+		if err != nil {
+			log.Fatalf("can not list groups, error: %+v", err)
+		}
+		for _, group := range groups {
+			fmt.Printf("%s\t%s\t%d users\n", group.Name, group.Domain.Name, group.UserCount)
+		}
+	},
+}
+
+// groupsCreateCmd represents the groups create command
+var groupsCreateCmd = &cobra.Command{
+	Use:   "create <group>",
+	Short: "Creates a local group",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		group, err := tabl.CreateGroup(args[0], tableau.SiteRoleType(tablMinimumSiteRole))
+		if err != nil {
+			log.Fatalf("can not create group, error: %+v", err)
+		}
+		fmt.Printf("created group %s (%s)\n", group.Name, group.Id)
+	},
+}
+
+// groupsRenameCmd represents the groups rename command
+var groupsRenameCmd = &cobra.Command{
+	Use:   "rename <group> <new name>",
+	Short: "Renames a local group",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		group, err := tabl.GetGroupByName(args[0])
+		if err != nil {
+			log.Fatalf("can not find group, error: %+v", err)
+		}
+		if _, err = tabl.RenameGroup(string(group.Id), args[1]); err != nil {
+			log.Fatalf("can not rename group, error: %+v", err)
+		}
+	},
+}
+
+// groupsDeleteCmd represents the groups delete command
+var groupsDeleteCmd = &cobra.Command{
+	Use:   "delete <group>",
+	Short: "Deletes a group, its members stay on the site",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		group, err := tabl.GetGroupByName(args[0])
+		if err != nil {
+			log.Fatalf("can not find group, error: %+v", err)
+		}
+		if err = tabl.DeleteGroup(string(group.Id)); err != nil {
+			log.Fatalf("can not delete group, error: %+v", err)
+		}
+	},
+}
+
+// groupsAddCmd represents the groups add command
+var groupsAddCmd = &cobra.Command{
+	Use:   "add <group> <user>...",
+	Short: "Adds users to a group",
+	Args:  cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		group, err := tabl.GetGroupByName(args[0])
+		if err != nil {
+			log.Fatalf("can not find group, error: %+v", err)
+		}
+		for _, name := range args[1:] {
+			user, err := tabl.GetUserByName(name)
+			if err != nil {
+				log.Fatalf("can not find user, error: %+v", err)
+			}
+			if _, err = tabl.AddUserToGroup(string(group.Id), string(user.Id)); err != nil {
+				log.Fatalf("can not add user to group, error: %+v", err)
+			}
+		}
+	},
+}
+
+// groupsRemoveCmd represents the groups remove command
+var groupsRemoveCmd = &cobra.Command{
+	Use:   "remove <group> <user>...",
+	Short: "Removes users from a group",
+	Args:  cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		group, err := tabl.GetGroupByName(args[0])
+		if err != nil {
+			log.Fatalf("can not find group, error: %+v", err)
+		}
+		for _, name := range args[1:] {
+			user, err := tabl.GetUserByName(name)
+			if err != nil {
+				log.Fatalf("can not find user, error: %+v", err)
+			}
+			if err = tabl.RemoveUserFromGroup(string(group.Id), string(user.Id)); err != nil {
+				log.Fatalf("can not remove user from group, error: %+v", err)
+			}
+		}
+	},
+}
+
+// groupsSyncCmd represents the groups sync command
+var groupsSyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Creates groups and adds/removes members to match a group membership yaml file",
+	Run: func(cmd *cobra.Command, args []string) {
+		membership, err := tableau.ReadGroupMembership(tablGroupsFile)
+		if err != nil {
+			log.Fatalf("can not read group membership, error: %+v", err)
+		}
+
+		tabl := signin()
+		defer signout(tabl)
+
+		changes, err := tabl.PlanGroupSync(membership, tablResync)
+		if err != nil {
+			log.Fatalf("can not plan group sync, error: %+v", err)
+		}
+		if len(changes) == 0 {
+			fmt.Println("groups are up to date")
+			return
+		}
+		fmt.Println("plan:")
+		for _, change := range changes {
+			fmt.Println(change)
+		}
+		if tablDryRun {
+			return
+		}
+
+		summary, err := tabl.ApplyGroupSync(changes)
+		fmt.Println(summary)
+		if err != nil {
+			log.Fatalf("can not apply group sync, error: %+v", err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(groupsCmd)
+	addSigninFlags(groupsCmd)
+
+	groupsCmd.AddCommand(groupsListCmd)
+	groupsCmd.AddCommand(groupsCreateCmd)
+	groupsCmd.AddCommand(groupsRenameCmd)
+	groupsCmd.AddCommand(groupsDeleteCmd)
+	groupsCmd.AddCommand(groupsAddCmd)
+	groupsCmd.AddCommand(groupsRemoveCmd)
+	groupsCmd.AddCommand(groupsSyncCmd)
+
+	groupsListCmd.Flags().StringVar(&tablFilter, "filter", "", "tableau filter expression, e.g. name:eq:Analysts")
+	groupsCreateCmd.Flags().StringVar(&tablMinimumSiteRole, "minimumSiteRole", "", "site role of members that are added to the site on sign in")
+
+	groupsSyncCmd.Flags().StringVarP(&tablGroupsFile, "file", "f", "", "yaml file with the desired members per group")
+	groupsSyncCmd.MarkFlagRequired("file")
+	groupsSyncCmd.Flags().BoolVar(&tablDryRun, "dry-run", false, "only print the plan, do not change anything")
+	groupsSyncCmd.Flags().BoolVar(&tablResync, "resync", false, "synchronize all Active Directory groups, also those whose import directive did not change")
+}
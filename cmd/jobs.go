@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jaby/tabgo/tableau"
+	"github.com/spf13/cobra"
+)
+
+var tablJobStatus string
+var tablJobType string
+var tablJobInterval time.Duration
+var tablJobTimeout time.Duration
+
+// exitCodeJobTimeout is the exit code of jobs wait when the job did not complete in time
+const exitCodeJobTimeout = 3
+
+// jobsCmd represents the jobs command
+var jobsCmd = &cobra.Command{
+	Use:   "jobs",
+	Short: "Lists, inspects, cancels and waits for the background jobs of a tableau site",
+}
+
+// jobsListCmd represents the jobs list command
+var jobsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the background jobs of a tableau site",
+	Run: func(cmd *cobra.Command, args []string) {
+		filters := []string{}
+		if tablFilter != "" {
+			filters = append(filters, tablFilter)
+		}
+		if tablJobStatus != "" {
+			filters = append(filters, "status:eq:"+tablJobStatus)
+		}
+		if tablJobType != "" {
+			filters = append(filters, "jobType:eq:"+tablJobType)
+		}
+
+		tabl := signin()
+		defer signout(tabl)
+
+		jobs, err := tabl.ListJobs(strings.Join(filters, ","))
+		if err != nil {
+			log.Fatalf("can not list jobs, error: %+v", err)
+		}
+		for _, job := range jobs {
+			fmt.Printf("%s\t%s\t%s\t%s\t%s\n", job.Id, job.JobType, job.Status, formatJobTime(job.CreatedAt), job.Title)
+		}
+	},
+}
+
+// jobsShowCmd represents the jobs show command
+var jobsShowCmd = &cobra.Command{
+	Use:   "show <job id>",
+	Short: "Shows the details of a job, including its status notes",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		job, err := tabl.QueryJob(args[0])
+		if err != nil {
+			log.Fatalf("can not query job, error: %+v", err)
+		}
+		printJob(job)
+	},
+}
+
+// jobsCancelCmd represents the jobs cancel command
+var jobsCancelCmd = &cobra.Command{
+	Use:   "cancel <job id>...",
+	Short: "Cancels pending or running jobs",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		for _, jobID := range args {
+			if err := tabl.CancelJob(jobID); err != nil {
+				log.Fatalf("can not cancel job, error: %+v", err)
+			}
+		}
+	},
+}
+
+// jobsWaitCmd represents the jobs wait command
+var jobsWaitCmd = &cobra.Command{
+	Use:   "wait <job id>",
+	Short: "Waits until a job completes, the exit code tells how it finished",
+	Long: `Waits until a job completes, the exit code tells how it finished:
+0 when the job succeeded, 1 when it failed (or tableau could not be queried), 2 when it was cancelled
+and 3 when it did not complete within the timeout.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		job, err := tabl.WaitForJob(args[0], tablJobInterval, tablJobTimeout)
+		signout(tabl)
+
+		if err == tableau.ErrJobTimeout {
+			fmt.Printf("job %s did not complete within %s (progress %d%%)\n", args[0], tablJobTimeout, job.Progress)
+			os.Exit(exitCodeJobTimeout)
+		}
+		if err != nil {
+			log.Fatalf("can not wait for job, error: %+v", err)
+		}
+		printJob(job)
+		os.Exit(job.FinishCode)
+	},
+}
+
+func printJob(job tableau.JobType) {
+	fmt.Printf("id:\t%s\n", job.Id)
+	fmt.Printf("type:\t%s\n", job.Type)
+	if content := tableau.DescribeJobContent(job); content != "" {
+		fmt.Printf("content:\t%s\n", content)
+	}
+	fmt.Printf("mode:\t%s\n", job.Mode)
+	fmt.Printf("progress:\t%d%%\n", job.Progress)
+	fmt.Printf("created:\t%s\n", formatJobTime(job.CreatedAt))
+	fmt.Printf("started:\t%s\n", formatJobTime(job.StartedAt))
+	fmt.Printf("completed:\t%s\n", formatJobTime(job.CompletedAt))
+	if tableau.IsJobCompleted(job) {
+		fmt.Printf("finish code:\t%d (%s)\n", job.FinishCode, tableau.DescribeFinishCode(job.FinishCode))
+	}
+	for _, note := range job.StatusNotes.StatusNote {
+		fmt.Printf("note:\t%s\t%s\t%s\n", note.Type, note.Value, note.Text)
+	}
+}
+
+func formatJobTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format("2006-01-02 15:04:05")
+}
+
+func init() {
+	rootCmd.AddCommand(jobsCmd)
+	addSigninFlags(jobsCmd)
+
+	jobsCmd.AddCommand(jobsListCmd)
+	jobsCmd.AddCommand(jobsShowCmd)
+	jobsCmd.AddCommand(jobsCancelCmd)
+	jobsCmd.AddCommand(jobsWaitCmd)
+
+	jobsListCmd.Flags().StringVar(&tablJobStatus, "status", "", "Pending, InProgress, Success, Failed or Cancelled")
+	jobsListCmd.Flags().StringVar(&tablJobType, "jobType", "", "type of the jobs, e.g. refresh_extracts")
+	jobsListCmd.Flags().StringVar(&tablFilter, "filter", "", "tableau filter expression, e.g. createdAt:gt:2020-01-01T00:00:00Z")
+
+	jobsWaitCmd.Flags().DurationVar(&tablJobInterval, "interval", 10*time.Second, "time between two checks of the job")
+	jobsWaitCmd.Flags().DurationVar(&tablJobTimeout, "timeout", 0, "maximum time to wait, 0 waits until the job completes")
+}
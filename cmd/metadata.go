@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"github.com/jaby/tabgo/tableau"
+	"github.com/spf13/cobra"
+)
+
+var tablGraphQLQuery string
+var tablGraphQLVariables map[string]string
+
+// metadataCmd represents the metadata command
+var metadataCmd = &cobra.Command{
+	Use:   "metadata",
+	Short: "Queries the lineage of tableau content with the metadata api",
+}
+
+// metadataQueryCmd represents the metadata query command
+var metadataQueryCmd = &cobra.Command{
+	Use:   "query [<graphql file>]",
+	Short: "Sends a graphql query to the metadata api and prints the json data of the response",
+	Long: `Sends a graphql query, read from a file, from stdin ("-") or given with --query, to the metadata api
+and prints the json data of the response.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		query := tablGraphQLQuery
+		if len(args) == 1 {
+			var content []byte
+			var err error
+			if args[0] == "-" {
+				content, err = ioutil.ReadAll(os.Stdin)
+			} else {
+				content, err = ioutil.ReadFile(args[0])
+			}
+			if err != nil {
+				log.Fatalf("can not read graphql query, error: %+v", err)
+			}
+			query = string(content)
+		}
+		if query == "" {
+			log.Fatalf("expecting a graphql file or --query")
+		}
+		variables := make(map[string]interface{})
+		for name, value := range tablGraphQLVariables {
+			variables[name] = value
+		}
+
+		tabl := signin()
+		defer signout(tabl)
+
+		data := json.RawMessage{}
+		if err := tabl.QueryMetadata(query, variables, &data); err != nil {
+			log.Fatalf("can not query metadata, error: %+v", err)
+		}
+		indented, err := json.MarshalIndent(data, "", "  ")
+		if err != nil {
+			log.Fatalf("can not json format metadata, error: %+v", err)
+		}
+		fmt.Println(string(indented))
+	},
+}
+
+// metadataTableCmd represents the metadata table command
+var metadataTableCmd = &cobra.Command{
+	Use:   "table <table name>",
+	Short: "Lists the workbooks and datasources that use a database table",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		tables, err := tabl.TableLineage(args[0])
+		if err != nil {
+			log.Fatalf("can not query table lineage, error: %+v", err)
+		}
+		if len(tables) == 0 {
+			fmt.Printf("no table '%s' found\n", args[0])
+		}
+		for _, table := range tables {
+			fmt.Printf("%s (%s %s)\n", table.FullName, table.Database.ConnectionType, table.Database.Name)
+			printLineage(table.Workbooks, table.Datasources)
+		}
+	},
+}
+
+// metadataServerCmd represents the metadata server command
+var metadataServerCmd = &cobra.Command{
+	Use:   "server <host name>",
+	Short: "Lists the workbooks and datasources that connect to a database server",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		servers, err := tabl.ServerLineage(args[0])
+		if err != nil {
+			log.Fatalf("can not query server lineage, error: %+v", err)
+		}
+		if len(servers) == 0 {
+			fmt.Printf("no database server '%s' found\n", args[0])
+		}
+		for _, server := range servers {
+			fmt.Printf("%s:%d (%s %s)\n", server.HostName, server.Port, server.ConnectionType, server.Name)
+			printLineage(server.Workbooks, server.Datasources)
+		}
+	},
+}
+
+func printLineage(workbooks, datasources []tableau.LineageContent) {
+	for _, workbook := range workbooks {
+		fmt.Printf("\tworkbook\t%s\n", workbook)
+	}
+	for _, datasource := range datasources {
+		fmt.Printf("\tdatasource\t%s\n", datasource)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(metadataCmd)
+	addSigninFlags(metadataCmd)
+
+	metadataCmd.AddCommand(metadataQueryCmd)
+	metadataCmd.AddCommand(metadataTableCmd)
+	metadataCmd.AddCommand(metadataServerCmd)
+
+	metadataQueryCmd.Flags().StringVarP(&tablGraphQLQuery, "query", "q", "", "graphql query")
+	metadataQueryCmd.Flags().StringToStringVar(&tablGraphQLVariables, "var", nil, "string variables of the query, e.g. --var name=orders")
+}
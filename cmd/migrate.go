@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/jaby/tabgo/tableau"
+	"github.com/spf13/cobra"
+)
+
+var tablTargetServerURL string
+var tablTargetUsername string
+var tablTargetPassword string
+var tablTargetSite string
+var tablMappingFile string
+var tablIncludeExtract bool
+var tablMigratePermissions bool
+
+// migrateCmd represents the migrate command
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Copies the workbooks and datasources of a project (and its nested projects) to another site or server",
+	Run: func(cmd *cobra.Command, args []string) {
+		mapping := tableau.MigrationMapping{}
+		if tablMappingFile != "" {
+			var err error
+			mapping, err = tableau.ReadMigrationMapping(tablMappingFile)
+			if err != nil {
+				log.Fatalf("can not read migration mapping, error: %+v", err)
+			}
+		}
+
+		source := signin()
+		defer signout(source)
+
+		migration := tableau.Migration{
+			Source:         source,
+			Mapping:        mapping,
+			IncludeExtract: tablIncludeExtract,
+			Permissions:    tablMigratePermissions,
+		}
+		items, err := migration.Plan(tablProjectName)
+		if err != nil {
+			log.Fatalf("can not plan migration, error: %+v", err)
+		}
+		if len(items) == 0 {
+			fmt.Println("nothing to migrate")
+			return
+		}
+		fmt.Println("plan:")
+		for _, item := range items {
+			fmt.Println(item)
+		}
+		if tablDryRun {
+			return
+		}
+
+		migration.Connections = readConnectionFinder()
+		migration.Target = signinTo(targetFlag(tablTargetServerURL, tablServerURL), targetFlag(tablTargetUsername, tablUsername),
+			targetFlag(tablTargetPassword, tablPassword), tablTargetSite)
+		defer signout(migration.Target)
+
+		failed := 0
+		for _, result := range migration.Run(items) {
+			fmt.Println(result)
+			if result.Err != nil {
+				failed++
+			}
+		}
+		fmt.Printf("%d migrated, %d failed\n", len(items)-failed, failed)
+		if failed > 0 {
+			signout(migration.Target)
+			signout(source)
+			os.Exit(1)
+		}
+	},
+}
+
+// targetFlag returns the value of a target flag, defaulting to the value of the corresponding source flag
+func targetFlag(target, source string) string {
+	if target != "" {
+		return target
+	}
+	return source
+}
+
+func init() {
+	rootCmd.AddCommand(migrateCmd)
+	addSigninFlags(migrateCmd)
+
+	migrateCmd.Flags().StringVar(&tablTargetServerURL, "targetUrl", "", "tableau server URL of the target, defaults to the source url")
+	migrateCmd.Flags().StringVar(&tablTargetUsername, "targetUsername", "", "tableau username on the target, defaults to the source username")
+	migrateCmd.Flags().StringVar(&tablTargetPassword, "targetPassword", "", "tableau password on the target, defaults to the source password")
+	migrateCmd.Flags().StringVar(&tablTargetSite, "targetSite", "", "tableau site to migrate to")
+	migrateCmd.MarkFlagRequired("targetSite")
+
+	migrateCmd.Flags().StringVarP(&tablProjectName, "project", "p", "", "source project path to migrate, e.g. Parent/Child (a / within a project name is escaped as \\/), the whole site when empty")
+	migrateCmd.Flags().StringVarP(&tablMappingFile, "mapping", "m", "", "yaml file mapping source project paths and owners to the target")
+	migrateCmd.Flags().StringVarP(&tablTargetConnections, "targetConnections", "t", "", "reference to target connections json file")
+	migrateCmd.MarkFlagRequired("targetConnections")
+
+	migrateCmd.Flags().BoolVar(&tablIncludeExtract, "includeExtract", true, "migrate extracts along with the documents")
+	migrateCmd.Flags().BoolVar(&tablMigratePermissions, "permissions", true, "copy explicit permissions, matching groups by name and users by (mapped) name")
+	migrateCmd.Flags().BoolVar(&tablDryRun, "dry-run", false, "only print the plan, do not change anything")
+}
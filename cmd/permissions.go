@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/jaby/tabgo/tableau"
+	"github.com/spf13/cobra"
+)
+
+var tablPermissionsFile string
+
+// permissionsCmd represents the permissions command
+var permissionsCmd = &cobra.Command{
+	Use:   "permissions",
+	Short: "Manages project permissions as code",
+}
+
+// permissionsApplyCmd represents the permissions apply command
+var permissionsApplyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Changes the project (default) permissions on tableau to match a permissions yaml file",
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl, changes := planPermissions()
+		defer signout(tabl)
+
+		if len(changes) == 0 {
+			fmt.Println("permissions are up to date")
+			return
+		}
+		fmt.Println("plan:")
+		for _, change := range changes {
+			fmt.Println(change)
+		}
+		if tablDryRun {
+			return
+		}
+
+		err := tabl.ApplyPermissionsSync(changes)
+		if err != nil {
+			log.Fatalf("can not apply permissions, error: %+v", err)
+		}
+		fmt.Printf("applied %d changes\n", len(changes))
+	},
+}
+
+// permissionsAuditCmd represents the permissions audit command
+var permissionsAuditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Reports the differences between a permissions yaml file and tableau, exits with code 2 on drift",
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl, changes := planPermissions()
+		signout(tabl)
+
+		if len(changes) == 0 {
+			fmt.Println("no permission drift")
+			return
+		}
+		fmt.Printf("permission drift (%d differences):\n", len(changes))
+		for _, change := range changes {
+			fmt.Println(change)
+		}
+		os.Exit(2)
+	},
+}
+
+// planPermissions signs in and plans the changes of the permissions file, exits when that is not possible
+func planPermissions() (*tableau.TabGo, []tableau.PermissionChange) {
+	spec, err := tableau.ReadPermissionsSpec(tablPermissionsFile)
+	if err != nil {
+		log.Fatalf("can not read permissions, error: %+v", err)
+	}
+
+	tabl := signin()
+	changes, err := tabl.PlanPermissionsSync(spec)
+	if err != nil {
+		log.Fatalf("can not compare permissions, error: %+v", err)
+	}
+	return tabl, changes
+}
+
+func init() {
+	rootCmd.AddCommand(permissionsCmd)
+	addSigninFlags(permissionsCmd)
+
+	permissionsCmd.AddCommand(permissionsApplyCmd)
+	permissionsCmd.AddCommand(permissionsAuditCmd)
+
+	permissionsCmd.PersistentFlags().StringVarP(&tablPermissionsFile, "file", "f", "", "yaml file with the desired permissions per project path")
+	permissionsCmd.MarkPersistentFlagRequired("file")
+
+	permissionsApplyCmd.Flags().BoolVar(&tablDryRun, "dry-run", false, "only print the plan, do not change anything")
+}
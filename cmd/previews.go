@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/jaby/tabgo/tableau"
+	"github.com/spf13/cobra"
+)
+
+var tablPreviewDir string
+var tablWorkbookID string
+
+// previewsCmd represents the previews command
+var previewsCmd = &cobra.Command{
+	Use:   "previews",
+	Short: "Saves the preview images of a workbook and its views, with their metadata, into a directory keyed by workbook id",
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		workbookID := tablWorkbookID
+		if workbookID == "" {
+			if tablWorkbookPath == "" {
+				log.Fatalf("expecting either --workbook or --id")
+			}
+			workbook, err := tabl.FindWorkbook(tablWorkbookPath)
+			if err != nil {
+				log.Fatalf("can not find workbook, error: %+v", err)
+			}
+			workbookID = string(workbook.Id)
+		}
+		savePreviews(tabl, workbookID, tablPreviewDir)
+	},
+}
+
+// savePreviews saves the previews of a workbook into dir and prints what changed
+func savePreviews(tabl *tableau.TabGo, workbookID, dir string) {
+	preview, err := tabl.SaveWorkbookPreviews(workbookID, dir)
+	if err != nil {
+		log.Fatalf("can not save previews, error: %+v", err)
+	}
+	changed := preview.ChangedViews()
+	fmt.Printf("saved previews of workbook %s (%s), %d of %d views changed", preview.Name, preview.WorkbookID, len(changed), len(preview.Views))
+	if len(changed) > 0 {
+		fmt.Printf(": %s", strings.Join(changed, ", "))
+	}
+	fmt.Println()
+}
+
+func init() {
+	rootCmd.AddCommand(previewsCmd)
+	addSigninFlags(previewsCmd)
+
+	previewsCmd.Flags().StringVar(&tablWorkbookPath, "workbook", "", "path of the workbook: its project path followed by its name, e.g. Finance/Reports/Revenue")
+	previewsCmd.Flags().StringVar(&tablWorkbookID, "id", "", "id of the workbook, e.g. as returned when publishing it")
+	previewsCmd.Flags().StringVarP(&tablPreviewDir, "dir", "o", "previews", "directory to save the previews in")
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/jaby/tabgo/tableau"
+	"github.com/spf13/cobra"
+)
+
+var tablShowIDs bool
+
+// projectsCmd represents the projects command
+var projectsCmd = &cobra.Command{
+	Use:   "projects",
+	Short: "Prints the project hierarchy of a tableau site",
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		roots, err := tabl.GetProjectTree()
+		if err != nil {
+			log.Fatalf("can not get projects of site '%s', error: %+v", tablSite, err)
+		}
+		for _, root := range roots {
+			root.Walk(printProjectNode)
+		}
+	},
+}
+
+func printProjectNode(node *tableau.ProjectNode, depth int) {
+	line := strings.Repeat("  ", depth) + node.Project.Name
+	if tablShowIDs {
+		line += fmt.Sprintf(" (%s)", node.Project.Id)
+	}
+	if node.Project.ContentPermissions != "" {
+		line += fmt.Sprintf(" [%s]", node.Project.ContentPermissions)
+	}
+	if node.Project.Description != "" {
+		line += fmt.Sprintf(" - %s", node.Project.Description)
+	}
+	fmt.Println(line)
+}
+
+func init() {
+	rootCmd.AddCommand(projectsCmd)
+	addSigninFlags(projectsCmd)
+
+	projectsCmd.Flags().BoolVar(&tablShowIDs, "ids", false, "also print the project ids")
+}
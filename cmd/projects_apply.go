@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/jaby/tabgo/tableau"
+	"github.com/spf13/cobra"
+)
+
+var tablHierarchyFile string
+var tablPrune bool
+var tablDryRun bool
+
+// projectsApplyCmd represents the projects apply command
+var projectsApplyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Creates, updates and optionally prunes projects to match a project hierarchy yaml file",
+	Run: func(cmd *cobra.Command, args []string) {
+		hierarchy, err := tableau.ReadProjectHierarchy(tablHierarchyFile)
+		if err != nil {
+			log.Fatalf("can not read project hierarchy, error: %+v", err)
+		}
+
+		tabl := signin()
+		defer signout(tabl)
+		applyPermissionTemplateFlag(tabl)
+
+		changes, err := tabl.PlanProjectSync(hierarchy, tablPrune)
+		if err != nil {
+			log.Fatalf("can not plan project sync, error: %+v", err)
+		}
+		if len(changes) == 0 {
+			fmt.Println("projects are up to date")
+			return
+		}
+		fmt.Println("plan:")
+		for _, change := range changes {
+			fmt.Println(change)
+		}
+		if tablDryRun {
+			return
+		}
+
+		err = tabl.ApplyProjectSync(changes)
+		if err != nil {
+			log.Fatalf("can not apply project sync, error: %+v", err)
+		}
+		applied := 0
+		for _, change := range changes {
+			if change.Skipped == "" {
+				applied++
+			}
+		}
+		fmt.Printf("applied %d changes\n", applied)
+	},
+}
+
+func init() {
+	projectsCmd.AddCommand(projectsApplyCmd)
+
+	projectsApplyCmd.Flags().StringVarP(&tablHierarchyFile, "file", "f", "", "yaml file describing the desired project hierarchy")
+	projectsApplyCmd.MarkFlagRequired("file")
+
+	projectsApplyCmd.Flags().BoolVar(&tablPrune, "prune", false, "delete projects that are not in the hierarchy file, projects that are not empty are skipped")
+	projectsApplyCmd.Flags().BoolVar(&tablDryRun, "dry-run", false, "only print the plan, do not change anything")
+	projectsApplyCmd.Flags().StringVar(&tablPermissionTemplate, "permissionTemplate", "", "permission template yaml file, applied to every project that is created")
+}
@@ -12,13 +12,11 @@ import (
 )
 
 var tablDocument string
-var tablServerURL string
-var tablUsername string
-var tablPassword string
-var tablSite string
 var tablProjectName string
 
 var tablTargetConnections string
+var tablPermissionTemplate string
+var tablPublishPreviewDir string
 
 type ExampleConnectionFinder struct {
 	connections map[string]tableau.Connection
@@ -36,34 +34,24 @@ var publishCmd = &cobra.Command{
 	Use:   "publish",
 	Short: "Publishes a datasource (file-extension: tds or tdsx) or a workbook (twb or twbx) to tableau ",
 	Run: func(cmd *cobra.Command, args []string) {
-		tabl := tableau.TabGo{ServerURL: tablServerURL, ApiVersion: "3.6"}
-		err := tabl.Signin(tablUsername, tablPassword, tablSite)
-		if err != nil {
-			log.Fatalf("unable to signin, error: %+v", err)
-		}
+		tabl := signin()
+		applyPermissionTemplateFlag(tabl)
 
-		// create a ConnectionFinder cfr tableau.ConnectionFinder interface
-		var connections map[string]tableau.Connection
-		targetConnectionsContent, err := ioutil.ReadFile(tablTargetConnections)
-
-		err = json.Unmarshal(targetConnectionsContent, &connections)
-		if err != nil {
-			log.Fatalf("can not read connections from %s, error: %+v", tablTargetConnections, err)
-		}
-		myConnectionFinder := ExampleConnectionFinder{connections: connections}
+		myConnectionFinder := readConnectionFinder()
 
 		startUpload := time.Now()
 		log.Printf(">>>>  start upload %s ", tablDocument)
-		_, err = tabl.PublishDocument(tablDocument, tablProjectName, myConnectionFinder)
+		tsResponse, err := tabl.PublishDocument(tablDocument, tablProjectName, myConnectionFinder)
 		if err != nil {
 			log.Fatalf("can not publish '%s' to project '%s' on site '%s',\nError: %+v ", tablDocument, tablProjectName, tablSite, err)
 		}
 		log.Printf(">>>>  upload of %s took: %s", tablDocument, time.Now().Sub(startUpload))
 
-		err = tabl.Signout()
-		if err != nil {
-			log.Fatalf("unable to signout")
+		if tablPublishPreviewDir != "" && tsResponse.Workbook.Id != "" {
+			savePreviews(tabl, string(tsResponse.Workbook.Id), tablPublishPreviewDir)
 		}
+
+		signout(tabl)
 	},
 }
 
@@ -73,21 +61,44 @@ func init() {
 	publishCmd.Flags().StringVarP(&tablDocument, "document", "d", "", "tableau document to publish, should have file-extension *.tds(x) for datasource or *twb(x) for workbook")
 	publishCmd.MarkFlagRequired("document")
 
-	publishCmd.Flags().StringVarP(&tablServerURL, "url", "u", "", "tableau server URL")
-	publishCmd.MarkFlagRequired("url")
+	addSigninFlags(publishCmd)
 
-	publishCmd.Flags().StringVarP(&tablUsername, "username", "n", "", "tableau username")
-	publishCmd.MarkFlagRequired("username")
+	publishCmd.Flags().StringVarP(&tablProjectName, "project", "p", "", "tableau project path within site, e.g. Parent/Child (a / within a project name is escaped as \\/), missing projects are created, defaults to the project of the document config")
 
-	publishCmd.Flags().StringVarP(&tablPassword, "password", "x", "", "tableau password")
-	publishCmd.MarkFlagRequired("password")
+	publishCmd.Flags().StringVarP(&tablTargetConnections, "targetConnections", "t", "", "reference to target connections json file, optional when the connections of the document config are complete")
 
-	publishCmd.Flags().StringVarP(&tablSite, "site", "s", "", "tableau site")
-	publishCmd.MarkFlagRequired("site")
+	publishCmd.Flags().StringVar(&tablPublishPreviewDir, "previews", "", "directory to save the preview images of a published workbook in, cfr previews command")
 
-	publishCmd.Flags().StringVarP(&tablProjectName, "project", "p", "", "tableau project within site")
-	publishCmd.MarkFlagRequired("project")
+	publishCmd.Flags().StringVar(&tablPermissionTemplate, "permissionTemplate", "", "permission template yaml file, applied to every project created while publishing")
+}
 
-	publishCmd.Flags().StringVarP(&tablTargetConnections, "targetConnections", "t", "", "reference to target connections json file")
-	publishCmd.MarkFlagRequired("targetConnections")
+// readConnectionFinder creates a ConnectionFinder (cfr tableau.ConnectionFinder interface)
+// from the json file of the targetConnections flag, exits when that is not possible.
+// It returns nil without a targetConnections flag, then only the connections of the document config are used.
+func readConnectionFinder() tableau.ConnectionFinder {
+	if tablTargetConnections == "" {
+		return nil
+	}
+	var connections map[string]tableau.Connection
+	targetConnectionsContent, err := ioutil.ReadFile(tablTargetConnections)
+	if err != nil {
+		log.Fatalf("can not read connections from %s, error: %+v", tablTargetConnections, err)
+	}
+	err = json.Unmarshal(targetConnectionsContent, &connections)
+	if err != nil {
+		log.Fatalf("can not read connections from %s, error: %+v", tablTargetConnections, err)
+	}
+	return ExampleConnectionFinder{connections: connections}
+}
+
+// applyPermissionTemplateFlag makes tabl apply the permission template of the permissionTemplate flag (if any) to new projects
+func applyPermissionTemplateFlag(tabl *tableau.TabGo) {
+	if tablPermissionTemplate == "" {
+		return
+	}
+	template, err := tableau.ReadPermissionTemplate(tablPermissionTemplate)
+	if err != nil {
+		log.Fatalf("can not read permission template, error: %+v", err)
+	}
+	tabl.NewProjectPermissions = &template
 }
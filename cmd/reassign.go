@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+)
+
+var tablFromUser string
+var tablToUser string
+
+// reassignCmd represents the reassign command
+var reassignCmd = &cobra.Command{
+	Use:   "reassign",
+	Short: "Transfers the workbooks, datasources, flows and projects of a user to another user",
+	Long: `Transfers the workbooks, datasources, flows and projects of a user to another user,
+e.g. when an analyst leaves. The content is found across the whole site, use --dry-run to preview it.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		from, err := tabl.GetUserByName(tablFromUser)
+		if err != nil {
+			log.Fatalf("can not find user, error: %+v", err)
+		}
+		to, err := tabl.GetUserByName(tablToUser)
+		if err != nil {
+			log.Fatalf("can not find user, error: %+v", err)
+		}
+
+		changes, err := tabl.PlanReassign(from, to)
+		if err != nil {
+			log.Fatalf("can not plan reassign, error: %+v", err)
+		}
+		if len(changes) == 0 {
+			fmt.Printf("%s owns no content\n", from.Name)
+			return
+		}
+		fmt.Println("plan:")
+		for _, change := range changes {
+			fmt.Println(change)
+		}
+		if tablDryRun {
+			return
+		}
+
+		if err = tabl.ApplyReassign(changes); err != nil {
+			log.Fatalf("can not reassign content, error: %+v", err)
+		}
+		fmt.Printf("applied %d changes\n", len(changes))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(reassignCmd)
+	addSigninFlags(reassignCmd)
+
+	reassignCmd.Flags().StringVar(&tablFromUser, "from", "", "name of the user whose content is transferred")
+	reassignCmd.MarkFlagRequired("from")
+	reassignCmd.Flags().StringVar(&tablToUser, "to", "", "name of the user the content is transferred to")
+	reassignCmd.MarkFlagRequired("to")
+	reassignCmd.Flags().BoolVar(&tablDryRun, "dry-run", false, "only print the plan, do not change anything")
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/jaby/tabgo/tableau"
+	"github.com/spf13/cobra"
+)
+
+var tablDatasourcePath string
+var tablWorkbookPath string
+var tablIncremental bool
+
+// refreshCmd represents the refresh command
+var refreshCmd = &cobra.Command{
+	Use:   "refresh",
+	Short: "Starts an extract refresh of a datasource or workbook now and prints the id of the job running it",
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		kind, id := contentFlags(tabl)
+		var job tableau.JobType
+		var err error
+		if kind == tableau.KindDatasource {
+			job, err = tabl.RefreshDatasource(id, tablIncremental)
+		} else {
+			job, err = tabl.RefreshWorkbook(id, tablIncremental)
+		}
+		if err != nil {
+			log.Fatalf("can not refresh extract, error: %+v", err)
+		}
+		fmt.Println(job.Id)
+	},
+}
+
+// contentFlags returns the kind and id of the datasource or workbook of the datasource/workbook flags,
+// exits when not exactly one of them is set or the content does not exist
+func contentFlags(tabl *tableau.TabGo) (tableau.ContentKind, string) {
+	switch {
+	case tablDatasourcePath != "" && tablWorkbookPath == "":
+		datasource, err := tabl.FindDatasource(tablDatasourcePath)
+		if err != nil {
+			log.Fatalf("can not find datasource, error: %+v", err)
+		}
+		return tableau.KindDatasource, string(datasource.Id)
+	case tablWorkbookPath != "" && tablDatasourcePath == "":
+		workbook, err := tabl.FindWorkbook(tablWorkbookPath)
+		if err != nil {
+			log.Fatalf("can not find workbook, error: %+v", err)
+		}
+		return tableau.KindWorkbook, string(workbook.Id)
+	}
+	log.Fatalf("expecting either --datasource or --workbook")
+	return "", ""
+}
+
+// addContentFlags adds the flags selecting a datasource or workbook to cmd
+func addContentFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&tablDatasourcePath, "datasource", "", "path of a datasource: its project path followed by its name, e.g. Finance/Sales")
+	cmd.Flags().StringVar(&tablWorkbookPath, "workbook", "", "path of a workbook: its project path followed by its name, e.g. Finance/Reports/Revenue")
+}
+
+func init() {
+	rootCmd.AddCommand(refreshCmd)
+	addSigninFlags(refreshCmd)
+	addContentFlags(refreshCmd)
+
+	refreshCmd.Flags().BoolVar(&tablIncremental, "incremental", false, "refresh incrementally, by running the incremental extract refresh task of the datasource or workbook")
+}
@@ -0,0 +1,270 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/jaby/tabgo/tableau"
+	"github.com/spf13/cobra"
+)
+
+var tablScheduleSpec tableau.ScheduleSpec
+var tablWeekDays []string
+var tablRefreshManifest string
+
+// schedulesCmd represents the schedules command
+var schedulesCmd = &cobra.Command{
+	Use:   "schedules",
+	Short: "Manages schedules and the extract refresh tasks that run on them",
+}
+
+// schedulesListCmd represents the schedules list command
+var schedulesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the schedules of a tableau server",
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		schedules, err := tabl.ListSchedules()
+		if err != nil {
+			log.Fatalf("can not list schedules, error: %+v", err)
+		}
+		for _, schedule := range schedules {
+			fmt.Printf("%s\t%s\t%s\t%s\t%s\n", schedule.Name, schedule.Type, schedule.Frequency, schedule.State, schedule.NextRunAt.Format("2006-01-02 15:04"))
+		}
+	},
+}
+
+// schedulesCreateCmd represents the schedules create command
+var schedulesCreateCmd = &cobra.Command{
+	Use:   "create <schedule>",
+	Short: "Creates a schedule",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		schedule := scheduleFlags(args[0])
+
+		tabl := signin()
+		defer signout(tabl)
+
+		schedule, err := tabl.CreateSchedule(schedule)
+		if err != nil {
+			log.Fatalf("can not create schedule, error: %+v", err)
+		}
+		fmt.Printf("created schedule %s (%s)\n", schedule.Name, schedule.Id)
+	},
+}
+
+// schedulesUpdateCmd represents the schedules update command
+var schedulesUpdateCmd = &cobra.Command{
+	Use:   "update <schedule>",
+	Short: "Changes the frequency, start, end, intervals, priority and/or execution order of a schedule",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		update := scheduleFlags(args[0])
+		update.Type = ""
+
+		tabl := signin()
+		defer signout(tabl)
+
+		schedule, err := tabl.GetScheduleByName(args[0])
+		if err != nil {
+			log.Fatalf("can not find schedule, error: %+v", err)
+		}
+		if _, err = tabl.UpdateSchedule(string(schedule.Id), update); err != nil {
+			log.Fatalf("can not update schedule, error: %+v", err)
+		}
+	},
+}
+
+// schedulesDeleteCmd represents the schedules delete command
+var schedulesDeleteCmd = &cobra.Command{
+	Use:   "delete <schedule>",
+	Short: "Deletes a schedule and all tasks that run on it",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		schedule, err := tabl.GetScheduleByName(args[0])
+		if err != nil {
+			log.Fatalf("can not find schedule, error: %+v", err)
+		}
+		if err = tabl.DeleteSchedule(string(schedule.Id)); err != nil {
+			log.Fatalf("can not delete schedule, error: %+v", err)
+		}
+	},
+}
+
+// schedulesTasksCmd represents the schedules tasks command
+var schedulesTasksCmd = &cobra.Command{
+	Use:   "tasks",
+	Short: "Lists the extract refresh tasks of a site",
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		tasks, err := tabl.ListExtractRefreshTasks()
+		if err != nil {
+			log.Fatalf("can not list extract refresh tasks, error: %+v", err)
+		}
+		for _, task := range tasks {
+			content := fmt.Sprintf("workbook %s", task.Workbook.Id)
+			if task.Datasource.Id != "" {
+				content = fmt.Sprintf("datasource %s", task.Datasource.Id)
+			}
+			refreshType := "full"
+			if tableau.IsIncrementalRefresh(task) {
+				refreshType = "incremental"
+			}
+			fmt.Printf("%s\t%s\t%s\t%s\n", task.Id, task.Schedule.Name, content, refreshType)
+		}
+	},
+}
+
+// schedulesAddCmd represents the schedules add command
+var schedulesAddCmd = &cobra.Command{
+	Use:   "add <schedule>",
+	Short: "Adds an extract refresh task for a datasource or workbook to a schedule",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		schedule, err := tabl.GetScheduleByName(args[0])
+		if err != nil {
+			log.Fatalf("can not find schedule, error: %+v", err)
+		}
+		kind, id := contentFlags(tabl)
+		var task tableau.TaskExtractRefreshType
+		if kind == tableau.KindDatasource {
+			task, err = tabl.AddDatasourceToSchedule(string(schedule.Id), id, tablIncremental)
+		} else {
+			task, err = tabl.AddWorkbookToSchedule(string(schedule.Id), id, tablIncremental)
+		}
+		if err != nil {
+			log.Fatalf("can not add extract refresh task, error: %+v", err)
+		}
+		fmt.Printf("added extract refresh task %s\n", task.Id)
+	},
+}
+
+// schedulesRemoveCmd represents the schedules remove command
+var schedulesRemoveCmd = &cobra.Command{
+	Use:   "remove <schedule>",
+	Short: "Removes the extract refresh task of a datasource or workbook from a schedule",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		schedule, err := tabl.GetScheduleByName(args[0])
+		if err != nil {
+			log.Fatalf("can not find schedule, error: %+v", err)
+		}
+		kind, id := contentFlags(tabl)
+		tasks, err := tabl.ListExtractRefreshTasks()
+		if err != nil {
+			log.Fatalf("can not list extract refresh tasks, error: %+v", err)
+		}
+		for _, task := range tasks {
+			taskContentID := task.Workbook.Id
+			if kind == tableau.KindDatasource {
+				taskContentID = task.Datasource.Id
+			}
+			if task.Schedule.Id == schedule.Id && string(taskContentID) == id {
+				if err = tabl.DeleteExtractRefreshTask(task.Id); err != nil {
+					log.Fatalf("can not remove extract refresh task, error: %+v", err)
+				}
+				return
+			}
+		}
+		log.Fatalf("no extract refresh task found on schedule '%s'", args[0])
+	},
+}
+
+// schedulesApplyCmd represents the schedules apply command
+var schedulesApplyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Creates and updates schedules and their extract refresh tasks to match a refresh manifest yaml file",
+	Run: func(cmd *cobra.Command, args []string) {
+		manifest, err := tableau.ReadRefreshManifest(tablRefreshManifest)
+		if err != nil {
+			log.Fatalf("can not read refresh manifest, error: %+v", err)
+		}
+
+		tabl := signin()
+		defer signout(tabl)
+
+		changes, err := tabl.PlanRefreshSync(manifest)
+		if err != nil {
+			log.Fatalf("can not plan refresh sync, error: %+v", err)
+		}
+		if len(changes) == 0 {
+			fmt.Println("schedules are up to date")
+			return
+		}
+		fmt.Println("plan:")
+		for _, change := range changes {
+			fmt.Println(change)
+		}
+		if tablDryRun {
+			return
+		}
+
+		if err = tabl.ApplyRefreshSync(changes); err != nil {
+			log.Fatalf("can not apply refresh sync, error: %+v", err)
+		}
+		fmt.Printf("applied %d changes\n", len(changes))
+	},
+}
+
+// scheduleFlags returns the schedule described by the schedule flags, exits when they are invalid
+func scheduleFlags(name string) tableau.ScheduleType {
+	spec := tablScheduleSpec
+	spec.Name = name
+	for _, weekDay := range tablWeekDays {
+		spec.WeekDays = append(spec.WeekDays, tableau.WeekDay(weekDay))
+	}
+	schedule, err := spec.Schedule()
+	if err != nil {
+		log.Fatalf("invalid schedule, error: %+v", err)
+	}
+	return schedule
+}
+
+func init() {
+	rootCmd.AddCommand(schedulesCmd)
+	addSigninFlags(schedulesCmd)
+
+	schedulesCmd.AddCommand(schedulesListCmd)
+	schedulesCmd.AddCommand(schedulesCreateCmd)
+	schedulesCmd.AddCommand(schedulesUpdateCmd)
+	schedulesCmd.AddCommand(schedulesDeleteCmd)
+	schedulesCmd.AddCommand(schedulesTasksCmd)
+	schedulesCmd.AddCommand(schedulesAddCmd)
+	schedulesCmd.AddCommand(schedulesRemoveCmd)
+	schedulesCmd.AddCommand(schedulesApplyCmd)
+
+	for _, cmd := range []*cobra.Command{schedulesCreateCmd, schedulesUpdateCmd} {
+		cmd.Flags().StringVar((*string)(&tablScheduleSpec.Type), "type", "Extract", "Extract, Flow or Subscription")
+		cmd.Flags().StringVar((*string)(&tablScheduleSpec.Frequency), "frequency", "", "Hourly, Daily or Weekly")
+		cmd.MarkFlagRequired("frequency")
+		cmd.Flags().StringVar(&tablScheduleSpec.Start, "start", "", "time of day the schedule starts, hh:mm")
+		cmd.MarkFlagRequired("start")
+		cmd.Flags().StringVar(&tablScheduleSpec.End, "end", "", "time of day an hourly schedule ends, hh:mm")
+		cmd.Flags().IntVar((*int)(&tablScheduleSpec.Hours), "hours", 0, "hours between runs of an hourly schedule (1, 2, 4, 6, 8 or 12)")
+		cmd.Flags().IntVar((*int)(&tablScheduleSpec.Minutes), "minutes", 0, "minutes between runs of an hourly schedule (15 or 30)")
+		cmd.Flags().StringSliceVar(&tablWeekDays, "weekDays", nil, "days of a weekly schedule, e.g. Monday,Friday")
+		cmd.Flags().IntVar(&tablScheduleSpec.Priority, "priority", 0, "priority of the schedule (1-100)")
+		cmd.Flags().StringVar((*string)(&tablScheduleSpec.ExecutionOrder), "executionOrder", "", "Parallel or Serial")
+	}
+
+	addContentFlags(schedulesAddCmd)
+	schedulesAddCmd.Flags().BoolVar(&tablIncremental, "incremental", false, "refresh the extract incrementally instead of fully")
+	addContentFlags(schedulesRemoveCmd)
+
+	schedulesApplyCmd.Flags().StringVarP(&tablRefreshManifest, "file", "f", "", "yaml file with the desired schedules and the datasources and workbooks they refresh")
+	schedulesApplyCmd.MarkFlagRequired("file")
+	schedulesApplyCmd.Flags().BoolVar(&tablDryRun, "dry-run", false, "only print the plan, do not change anything")
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/jaby/tabgo/tableau"
+	"github.com/spf13/cobra"
+)
+
+var tablServerURL string
+var tablUsername string
+var tablPassword string
+var tablSite string
+var tablApiVersion string
+
+// addSigninFlags adds the flags needed to sign in to a tableau site to cmd and all of its subcommands
+func addSigninFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVarP(&tablServerURL, "url", "u", "", "tableau server URL")
+	cmd.MarkPersistentFlagRequired("url")
+
+	cmd.PersistentFlags().StringVarP(&tablUsername, "username", "n", "", "tableau username")
+	cmd.MarkPersistentFlagRequired("username")
+
+	cmd.PersistentFlags().StringVarP(&tablPassword, "password", "x", "", "tableau password")
+	cmd.MarkPersistentFlagRequired("password")
+
+	cmd.PersistentFlags().StringVarP(&tablSite, "site", "s", "", "tableau site")
+	cmd.MarkPersistentFlagRequired("site")
+
+	cmd.PersistentFlags().StringVar(&tablApiVersion, "apiVersion", "3.6", "tableau rest api version")
+}
+
+// signin signs in to tableau with the values of the signin flags, exits when that is not possible
+func signin() *tableau.TabGo {
+	return signinTo(tablServerURL, tablUsername, tablPassword, tablSite)
+}
+
+// signinTo signs in to a site of a tableau server, exits when that is not possible
+func signinTo(serverURL, username, password, site string) *tableau.TabGo {
+	tabl := &tableau.TabGo{ServerURL: serverURL, ApiVersion: tablApiVersion}
+	err := tabl.Signin(username, password, site)
+	if err != nil {
+		log.Fatalf("unable to signin to site '%s' of %s, error: %+v", site, serverURL, err)
+	}
+	return tabl
+}
+
+// signout signs out of tableau, exits when that is not possible
+func signout(tabl *tableau.TabGo) {
+	err := tabl.Signout()
+	if err != nil {
+		log.Fatalf("unable to signout")
+	}
+}
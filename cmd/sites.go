@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/jaby/tabgo/tableau"
+	"github.com/spf13/cobra"
+)
+
+var tablSiteName string
+var tablAdminMode string
+var tablSiteState string
+var tablUserQuota int
+var tablStorageQuota int
+var tablRevisionLimit string
+
+// sitesCmd represents the sites command
+var sitesCmd = &cobra.Command{
+	Use:   "sites",
+	Short: "Manages the sites of a tableau server",
+}
+
+// sitesListCmd represents the sites list command
+var sitesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the sites of a tableau server",
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		sites, err := tabl.ListSites()
+		if err != nil {
+			log.Fatalf("can not list sites, error: %+v", err)
+		}
+		for _, site := range sites {
+			fmt.Printf("%s\t%s\t%s\t%s\n", site.ContentUrl, site.Name, site.State, site.AdminMode)
+		}
+	},
+}
+
+// sitesShowCmd represents the sites show command
+var sitesShowCmd = &cobra.Command{
+	Use:   "show <contentUrl>",
+	Short: "Shows the settings and usage of a site",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		site, err := tabl.QuerySiteByContentURL(args[0])
+		if err != nil {
+			log.Fatalf("can not query site, error: %+v", err)
+		}
+		printSite(site)
+	},
+}
+
+// sitesCreateCmd represents the sites create command
+var sitesCreateCmd = &cobra.Command{
+	Use:   "create <contentUrl>",
+	Short: "Creates a site",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		settings := siteFlags()
+		settings.ContentUrl = args[0]
+		if settings.Name == "" {
+			settings.Name = args[0]
+		}
+		site, err := tabl.CreateSite(settings)
+		if err != nil {
+			log.Fatalf("can not create site, error: %+v", err)
+		}
+		printSite(site)
+	},
+}
+
+// sitesUpdateCmd represents the sites update command
+var sitesUpdateCmd = &cobra.Command{
+	Use:   "update <contentUrl>",
+	Short: "Changes the name, admin mode, state, quotas and/or revision limit of a site",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		site, err := tabl.QuerySiteByContentURL(args[0])
+		if err != nil {
+			log.Fatalf("can not query site, error: %+v", err)
+		}
+		site, err = tabl.UpdateSite(string(site.Id), siteFlags())
+		if err != nil {
+			log.Fatalf("can not update site, error: %+v", err)
+		}
+		printSite(site)
+	},
+}
+
+// siteFlags returns the site settings given on the command line
+func siteFlags() tableau.SiteType {
+	site := tableau.SiteType{
+		Name:          tablSiteName,
+		AdminMode:     tableau.AdminMode(tablAdminMode),
+		State:         tableau.StateType(tablSiteState),
+		UserQuota:     tablUserQuota,
+		StorageQuota:  tablStorageQuota,
+		RevisionLimit: tableau.RevisionLimitType(tablRevisionLimit),
+	}
+	site.RevisionHistoryEnabled = site.RevisionLimit != ""
+	return site
+}
+
+func printSite(site tableau.SiteType) {
+	fmt.Printf("id:            %s\n", site.Id)
+	fmt.Printf("name:          %s\n", site.Name)
+	fmt.Printf("contentUrl:    %s\n", site.ContentUrl)
+	fmt.Printf("state:         %s\n", site.State)
+	fmt.Printf("adminMode:     %s\n", site.AdminMode)
+	fmt.Printf("userQuota:     %d\n", site.UserQuota)
+	fmt.Printf("storageQuota:  %d MB\n", site.StorageQuota)
+	fmt.Printf("revisionLimit: %s\n", site.RevisionLimit)
+	fmt.Printf("usage:         %d users, %d MB\n", site.Usage.NumUsers, site.Usage.Storage)
+}
+
+func init() {
+	rootCmd.AddCommand(sitesCmd)
+	addSigninFlags(sitesCmd)
+
+	sitesCmd.AddCommand(sitesListCmd)
+	sitesCmd.AddCommand(sitesShowCmd)
+	sitesCmd.AddCommand(sitesCreateCmd)
+	sitesCmd.AddCommand(sitesUpdateCmd)
+
+	for _, cmd := range []*cobra.Command{sitesCreateCmd, sitesUpdateCmd} {
+		cmd.Flags().StringVar(&tablSiteName, "name", "", "display name of the site")
+		cmd.Flags().StringVar(&tablAdminMode, "adminMode", "", "ContentAndUsers or ContentOnly")
+		cmd.Flags().IntVar(&tablUserQuota, "userQuota", 0, "maximum number of users on the site")
+		cmd.Flags().IntVar(&tablStorageQuota, "storageQuota", 0, "maximum storage of the site, in MB")
+		cmd.Flags().StringVar(&tablRevisionLimit, "revisionLimit", "", "number of revisions kept per workbook or datasource (2-10000, -1 for unlimited)")
+	}
+	sitesUpdateCmd.Flags().StringVar(&tablSiteState, "state", "", "Active or Suspended")
+}
@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/jaby/tabgo/tableau"
+	"github.com/spf13/cobra"
+)
+
+var tablViewPath string
+var tablSubscriptionUser string
+var tablSubscriptionSchedule string
+var tablSubscription tableau.SubscriptionType
+var tablFromWorkbook string
+var tablToWorkbook string
+
+// subscriptionsCmd represents the subscriptions command
+var subscriptionsCmd = &cobra.Command{
+	Use:   "subscriptions",
+	Short: "Manages the subscriptions of users to views and workbooks",
+}
+
+// subscriptionsListCmd represents the subscriptions list command
+var subscriptionsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the subscriptions of a tableau site",
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		subscriptions, err := tabl.ListSubscriptions()
+		if err != nil {
+			log.Fatalf("can not list subscriptions, error: %+v", err)
+		}
+		for _, subscription := range subscriptions {
+			fmt.Printf("%s\t%s\t%s\t%s\t%s %s\n", subscription.Id, subscription.Subject, subscription.User.Name, subscription.Schedule.Name,
+				subscription.Content.Type, subscription.Content.Id)
+		}
+	},
+}
+
+// subscriptionsCreateCmd represents the subscriptions create command
+var subscriptionsCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Subscribes a user to a view or workbook on a schedule",
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		subscription := tablSubscription
+		switch {
+		case tablViewPath != "" && tablWorkbookPath == "":
+			view, err := tabl.FindView(tablViewPath)
+			if err != nil {
+				log.Fatalf("can not find view, error: %+v", err)
+			}
+			subscription.Content = tableau.SubscriptionContentType{Id: view.Id, Type: tableau.SubscriptionContentView}
+		case tablWorkbookPath != "" && tablViewPath == "":
+			workbook, err := tabl.FindWorkbook(tablWorkbookPath)
+			if err != nil {
+				log.Fatalf("can not find workbook, error: %+v", err)
+			}
+			subscription.Content = tableau.SubscriptionContentType{Id: workbook.Id, Type: tableau.SubscriptionContentWorkbook}
+		default:
+			log.Fatalf("expecting either --view or --workbook")
+		}
+
+		user, err := tabl.GetUserByName(tablSubscriptionUser)
+		if err != nil {
+			log.Fatalf("can not find user, error: %+v", err)
+		}
+		subscription.User = user
+		if subscription.Schedule, err = tabl.GetScheduleByName(tablSubscriptionSchedule); err != nil {
+			log.Fatalf("can not find schedule, error: %+v", err)
+		}
+
+		created, err := tabl.CreateSubscription(subscription)
+		if err != nil {
+			log.Fatalf("can not create subscription, error: %+v", err)
+		}
+		fmt.Printf("created subscription %s\n", created.Id)
+	},
+}
+
+// subscriptionsUpdateCmd represents the subscriptions update command
+var subscriptionsUpdateCmd = &cobra.Command{
+	Use:   "update <subscription id>",
+	Short: "Changes the subject, schedule and/or attachments of a subscription",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		subscription, err := tabl.QuerySubscription(args[0])
+		if err != nil {
+			log.Fatalf("can not find subscription, error: %+v", err)
+		}
+		// only the given flags change the subscription
+		if cmd.Flags().Changed("subject") {
+			subscription.Subject = tablSubscription.Subject
+		}
+		if cmd.Flags().Changed("attachImage") {
+			subscription.AttachImage = tablSubscription.AttachImage
+		}
+		if cmd.Flags().Changed("attachPdf") {
+			subscription.AttachPdf = tablSubscription.AttachPdf
+		}
+		subscription.Schedule = tableau.ScheduleType{}
+		if tablSubscriptionSchedule != "" {
+			if subscription.Schedule, err = tabl.GetScheduleByName(tablSubscriptionSchedule); err != nil {
+				log.Fatalf("can not find schedule, error: %+v", err)
+			}
+		}
+
+		if _, err = tabl.UpdateSubscription(args[0], subscription); err != nil {
+			log.Fatalf("can not update subscription, error: %+v", err)
+		}
+	},
+}
+
+// subscriptionsDeleteCmd represents the subscriptions delete command
+var subscriptionsDeleteCmd = &cobra.Command{
+	Use:   "delete <subscription id>...",
+	Short: "Deletes subscriptions",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		for _, subscriptionID := range args {
+			if err := tabl.DeleteSubscription(subscriptionID); err != nil {
+				log.Fatalf("can not delete subscription, error: %+v", err)
+			}
+		}
+	},
+}
+
+// subscriptionsCopyCmd represents the subscriptions copy command
+var subscriptionsCopyCmd = &cobra.Command{
+	Use:   "copy",
+	Short: "Copies the subscriptions of a workbook and its views to another workbook, e.g. its republished replacement",
+	Long: `Copies the subscriptions of a workbook and its views to another workbook, e.g. its republished replacement.
+The views of the workbooks are matched by name, subscriptions the target already has are skipped.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		fromID := workbookPathOrID(tabl, tablFromWorkbook)
+		toID := workbookPathOrID(tabl, tablToWorkbook)
+		copies, err := tabl.PlanSubscriptionCopy(fromID, toID)
+		if err != nil {
+			log.Fatalf("can not plan subscription copy, error: %+v", err)
+		}
+		if len(copies) == 0 {
+			fmt.Println("no subscriptions to copy")
+			return
+		}
+		fmt.Println("plan:")
+		skipped := 0
+		for _, subscriptionCopy := range copies {
+			fmt.Println(subscriptionCopy)
+			if subscriptionCopy.Skipped != "" {
+				skipped++
+			}
+		}
+		if tablDryRun {
+			return
+		}
+
+		if err = tabl.CopySubscriptions(copies); err != nil {
+			log.Fatalf("can not copy subscriptions, error: %+v", err)
+		}
+		fmt.Printf("copied %d subscriptions, skipped %d\n", len(copies)-skipped, skipped)
+	},
+}
+
+// workbookPathOrID returns the id of a workbook given by its path (cfr FindWorkbook) or by its id
+func workbookPathOrID(tabl *tableau.TabGo, pathOrID string) string {
+	if !strings.Contains(pathOrID, "/") {
+		return pathOrID
+	}
+	workbook, err := tabl.FindWorkbook(pathOrID)
+	if err != nil {
+		log.Fatalf("can not find workbook, error: %+v", err)
+	}
+	return string(workbook.Id)
+}
+
+func init() {
+	rootCmd.AddCommand(subscriptionsCmd)
+	addSigninFlags(subscriptionsCmd)
+
+	subscriptionsCmd.AddCommand(subscriptionsListCmd)
+	subscriptionsCmd.AddCommand(subscriptionsCreateCmd)
+	subscriptionsCmd.AddCommand(subscriptionsUpdateCmd)
+	subscriptionsCmd.AddCommand(subscriptionsDeleteCmd)
+	subscriptionsCmd.AddCommand(subscriptionsCopyCmd)
+
+	subscriptionsCreateCmd.Flags().StringVar(&tablViewPath, "view", "", "path of a view: the path of its workbook followed by its name, e.g. Finance/Reports/Revenue/Overview")
+	subscriptionsCreateCmd.Flags().StringVar(&tablWorkbookPath, "workbook", "", "path of a workbook: its project path followed by its name, e.g. Finance/Reports/Revenue")
+	subscriptionsCreateCmd.Flags().StringVar(&tablSubscriptionUser, "user", "", "name of the subscribed user")
+	subscriptionsCreateCmd.MarkFlagRequired("user")
+	for _, cmd := range []*cobra.Command{subscriptionsCreateCmd, subscriptionsUpdateCmd} {
+		cmd.Flags().StringVar(&tablSubscriptionSchedule, "schedule", "", "name of the subscription schedule")
+		cmd.Flags().StringVar(&tablSubscription.Subject, "subject", "", "subject of the subscription emails")
+		cmd.Flags().BoolVar(&tablSubscription.AttachImage, "attachImage", true, "include an image of the content in the emails")
+		cmd.Flags().BoolVar(&tablSubscription.AttachPdf, "attachPdf", false, "attach a pdf of the content to the emails")
+	}
+	subscriptionsCreateCmd.MarkFlagRequired("schedule")
+	subscriptionsCreateCmd.MarkFlagRequired("subject")
+
+	subscriptionsCopyCmd.Flags().StringVar(&tablFromWorkbook, "from", "", "workbook whose subscriptions are copied, by path (e.g. Finance/Reports/Revenue) or id")
+	subscriptionsCopyCmd.MarkFlagRequired("from")
+	subscriptionsCopyCmd.Flags().StringVar(&tablToWorkbook, "to", "", "workbook the subscriptions are copied to, by path or id")
+	subscriptionsCopyCmd.MarkFlagRequired("to")
+	subscriptionsCopyCmd.Flags().BoolVar(&tablDryRun, "dry-run", false, "only print the plan, do not change anything")
+}
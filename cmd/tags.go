@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/jaby/tabgo/tableau"
+	"github.com/spf13/cobra"
+)
+
+var tablTagsFile string
+var tablTagKind string
+var tablTagsAdd []string
+var tablTagsRemove []string
+
+// tagsCmd represents the tags command
+var tagsCmd = &cobra.Command{
+	Use:   "tags",
+	Short: "Tags the workbooks, datasources, views and flows of a tableau site",
+}
+
+// tagsListCmd represents the tags list command
+var tagsListCmd = &cobra.Command{
+	Use:   "list <tag>",
+	Short: "Lists the workbooks, datasources, views and flows with a tag",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		contents, err := tabl.ListTaggedContent(args[0])
+		if err != nil {
+			log.Fatalf("can not list tagged content, error: %+v", err)
+		}
+		for _, content := range contents {
+			fmt.Printf("%s\t%s\t%s\n", content.Kind, content.Id, content.Path)
+		}
+	},
+}
+
+// tagsApplyCmd represents the tags apply command
+var tagsApplyCmd = &cobra.Command{
+	Use:   "apply [<content pattern>...]",
+	Short: "Adds and removes tags on the content matching a tag manifest or content patterns",
+	Long: `Adds and removes tags on the content matching a tag manifest (--file) or content patterns.
+A content pattern is a glob of content paths, the project path followed by the name (e.g. "Finance/*/Sales*"),
+for views the workbook path followed by the name. A "*" does not match "/".`,
+	Run: func(cmd *cobra.Command, args []string) {
+		manifest := tableau.TagManifest{}
+		switch {
+		case tablTagsFile != "" && len(args) == 0:
+			var err error
+			if manifest, err = tableau.ReadTagManifest(tablTagsFile); err != nil {
+				log.Fatalf("can not read tag manifest, error: %+v", err)
+			}
+		case tablTagsFile == "" && len(args) > 0:
+			for _, pattern := range args {
+				manifest.Rules = append(manifest.Rules, tableau.TagRule{Content: pattern, Kind: tableau.ContentKind(tablTagKind),
+					Add: tablTagsAdd, Remove: tablTagsRemove})
+			}
+			if err := manifest.Validate(); err != nil {
+				log.Fatalf("invalid tags, error: %+v", err)
+			}
+		default:
+			log.Fatalf("expecting either --file or content patterns")
+		}
+
+		tabl := signin()
+		defer signout(tabl)
+
+		changes, err := tabl.PlanTags(manifest)
+		if err != nil {
+			log.Fatalf("can not plan tags, error: %+v", err)
+		}
+		if len(changes) == 0 {
+			fmt.Println("tags are up to date")
+			return
+		}
+		fmt.Println("plan:")
+		for _, change := range changes {
+			fmt.Println(change)
+		}
+		if tablDryRun {
+			return
+		}
+
+		if err = tabl.ApplyTags(changes); err != nil {
+			log.Fatalf("can not apply tags, error: %+v", err)
+		}
+		fmt.Printf("applied %d changes\n", len(changes))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(tagsCmd)
+	addSigninFlags(tagsCmd)
+
+	tagsCmd.AddCommand(tagsListCmd)
+	tagsCmd.AddCommand(tagsApplyCmd)
+
+	tagsApplyCmd.Flags().StringVarP(&tablTagsFile, "file", "f", "", "yaml file with the tags to add and remove per content pattern")
+	tagsApplyCmd.Flags().StringVar(&tablTagKind, "kind", "", "only tag content of this kind: workbook, datasource, view or flow, default all")
+	tagsApplyCmd.Flags().StringSliceVar(&tablTagsAdd, "add", nil, "tags to add to the content matching the patterns")
+	tagsApplyCmd.Flags().StringSliceVar(&tablTagsRemove, "remove", nil, "tags to remove from the content matching the patterns")
+	tagsApplyCmd.Flags().BoolVar(&tablDryRun, "dry-run", false, "only print the plan, do not change anything")
+}
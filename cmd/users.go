@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/jaby/tabgo/tableau"
+	"github.com/spf13/cobra"
+)
+
+var tablFilter string
+
+// usersCmd represents the users command
+var usersCmd = &cobra.Command{
+	Use:   "users",
+	Short: "Manages the users of a tableau site",
+}
+
+// usersListCmd represents the users list command
+var usersListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the users of a tableau site",
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		users, err := tabl.ListUsers(tablFilter)
+		if err != nil {
+			log.Fatalf("can not list users, error: %+v", err)
+		}
+		for _, user := range users {
+			fmt.Printf("%s\t%s\t%s\t%s\n", user.Name, user.FullName, user.SiteRole, user.AuthSetting)
+		}
+	},
+}
+
+// usersImportCmd represents the users import command
+var usersImportCmd = &cobra.Command{
+	Use:   "import <users.csv>",
+	Short: "Creates or updates the users of a csv file (columns: name,fullName,email,siteRole,authSetting,password)",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		rows, err := tableau.ReadUserImportCSV(args[0])
+		if err != nil {
+			log.Fatalf("can not read users, error: %+v", err)
+		}
+
+		tabl := signin()
+		results := tabl.ImportUsers(rows)
+		signout(tabl)
+
+		counts := make(map[tableau.UserImportAction]int)
+		for _, result := range results {
+			fmt.Println(result)
+			counts[result.Action]++
+		}
+		fmt.Printf("%d created, %d updated, %d unchanged, %d failed\n",
+			counts[tableau.UserCreated], counts[tableau.UserUpdated], counts[tableau.UserUnchanged], counts[tableau.UserFailed])
+		if counts[tableau.UserFailed] > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(usersCmd)
+	addSigninFlags(usersCmd)
+
+	usersCmd.AddCommand(usersListCmd)
+	usersCmd.AddCommand(usersImportCmd)
+
+	usersListCmd.Flags().StringVar(&tablFilter, "filter", "", "tableau filter expression, e.g. siteRole:eq:Explorer")
+}
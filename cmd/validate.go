@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jaby/tabgo/tableau"
+	"github.com/spf13/cobra"
+)
+
+// validateCmd represents the validate command
+var validateCmd = &cobra.Command{
+	Use:   "validate <document, config file or directory>...",
+	Short: "Validates the publish config files of documents, without contacting tableau",
+	Long: `Validates the publish config files of documents, without contacting tableau.
+A document (tds, tdsx, twb or twbx) is configured by a file next to it: <document>.tabgo.yaml, .tabgo.yml or .tabgo.json.
+For a directory all documents within it are validated, as well as config files without a document.
+Exits with 1 when a config is invalid or its document is missing.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		invalid := 0
+		for _, arg := range args {
+			err := filepath.Walk(arg, func(path string, info os.FileInfo, err error) error {
+				if err != nil {
+					return err
+				}
+				if info.IsDir() || (path != arg && !tableau.IsDocument(path) && !tableau.IsDocumentConfig(path)) {
+					return nil
+				}
+				if path != arg && tableau.IsDocumentConfig(path) && documentExists(path) {
+					// validated along with its document
+					return nil
+				}
+				if !validatePath(path) {
+					invalid++
+				}
+				return nil
+			})
+			if err != nil {
+				fmt.Printf("%s: %v\n", arg, err)
+				invalid++
+			}
+		}
+		if invalid > 0 {
+			fmt.Printf("%d invalid\n", invalid)
+			os.Exit(1)
+		}
+	},
+}
+
+// validatePath validates a document config, or the config of a document, and prints the outcome
+func validatePath(path string) bool {
+	configPath := path
+	if tableau.IsDocument(path) {
+		var err error
+		if configPath, err = tableau.FindDocumentConfig(path); err != nil {
+			fmt.Printf("%s: %v\n", path, err)
+			return false
+		}
+		if configPath == "" {
+			fmt.Printf("%s: no config\n", path)
+			return true
+		}
+	}
+	if !documentExists(configPath) {
+		fmt.Printf("%s: document %s not found\n", configPath, tableau.DocumentOfConfig(configPath))
+		return false
+	}
+	if _, err := tableau.ReadDocumentConfig(configPath); err != nil {
+		// the error names the config file
+		fmt.Println(err)
+		return false
+	}
+	fmt.Printf("%s: ok\n", configPath)
+	return true
+}
+
+// documentExists tells whether the document configured by the config file at configPath exists
+func documentExists(configPath string) bool {
+	_, err := os.Stat(tableau.DocumentOfConfig(configPath))
+	return err == nil
+}
+
+func init() {
+	rootCmd.AddCommand(validateCmd)
+}
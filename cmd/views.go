@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/jaby/tabgo/tableau"
+	"github.com/spf13/cobra"
+)
+
+// viewsCmd represents the views command
+var viewsCmd = &cobra.Command{
+	Use:   "views",
+	Short: "Lists the views of a tableau site",
+}
+
+// viewsListCmd represents the views list command
+var viewsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the views of a tableau site or of a workbook",
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		var views []tableau.ViewType
+		var err error
+		if tablWorkbookPath != "" {
+			var workbook tableau.WorkbookType
+			if workbook, err = tabl.FindWorkbook(tablWorkbookPath); err != nil {
+				log.Fatalf("can not find workbook, error: %+v", err)
+			}
+			views, err = tabl.ListWorkbookViews(string(workbook.Id))
+		} else {
+			views, err = tabl.ListViews(tablFilter)
+		}
+		if err != nil {
+			log.Fatalf("can not list views, error: %+v", err)
+		}
+		for _, view := range views {
+			fmt.Printf("%s\t%s\t%s\t%s\n", view.Id, view.Name, view.SheetType, view.ContentUrl)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(viewsCmd)
+	addSigninFlags(viewsCmd)
+
+	viewsCmd.AddCommand(viewsListCmd)
+
+	viewsListCmd.Flags().StringVar(&tablWorkbookPath, "workbook", "", "only list the views of this workbook: its project path followed by its name, e.g. Finance/Reports/Revenue")
+	viewsListCmd.Flags().StringVar(&tablFilter, "filter", "", "tableau filter expression, e.g. name:eq:Overview")
+}
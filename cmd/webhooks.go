@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/jaby/tabgo/tableau"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var tablWebhookEvent string
+var tablWebhookURL string
+var tablListenAddress string
+var tablListenPath string
+var tablListenEvents []string
+var tablListenExec string
+
+// webhooksCmd represents the webhooks command
+var webhooksCmd = &cobra.Command{
+	Use:   "webhooks",
+	Short: "Manages the webhooks of a tableau site and receives their events",
+}
+
+// webhooksListCmd represents the webhooks list command
+var webhooksListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the webhooks of a tableau site with their event and destination",
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		webhooks, err := tabl.ListWebhooks()
+		if err != nil {
+			log.Fatalf("can not list webhooks, error: %+v", err)
+		}
+		for _, webhook := range webhooks {
+			fmt.Printf("%s\t%s\t%s\t%s\tenabled=%t\n", webhook.Id, webhook.Name, webhook.Event,
+				webhook.Webhookdestination.Webhookdestinationhttp.Url, webhook.Enabled)
+		}
+	},
+}
+
+// webhooksCreateCmd represents the webhooks create command
+var webhooksCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Creates a webhook posting the payload of an event to an https url",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		event, err := parseWebhookEvent(tablWebhookEvent)
+		if err != nil {
+			log.Fatalf("can not create webhook, error: %+v", err)
+		}
+
+		tabl := signin()
+		defer signout(tabl)
+
+		webhook, err := tabl.CreateWebhook(args[0], event, tablWebhookURL)
+		if err != nil {
+			log.Fatalf("can not create webhook, error: %+v", err)
+		}
+		fmt.Printf("created webhook %s\n", webhook.Id)
+	},
+}
+
+// webhooksTestCmd represents the webhooks test command
+var webhooksTestCmd = &cobra.Command{
+	Use:   "test <name>",
+	Short: "Makes tableau send a test payload to the destination of a webhook",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		webhook, err := tabl.GetWebhookByName(args[0])
+		if err != nil {
+			log.Fatalf("can not find webhook, error: %+v", err)
+		}
+		result, err := tabl.TestWebhook(string(webhook.Id))
+		if err != nil {
+			log.Fatalf("can not test webhook, error: %+v", err)
+		}
+		fmt.Printf("status:\t%d\n", result.Status)
+		fmt.Printf("body:\t%s\n", result.Body)
+	},
+}
+
+// webhooksDeleteCmd represents the webhooks delete command
+var webhooksDeleteCmd = &cobra.Command{
+	Use:   "delete <name>...",
+	Short: "Deletes webhooks",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		tabl := signin()
+		defer signout(tabl)
+
+		for _, name := range args {
+			webhook, err := tabl.GetWebhookByName(name)
+			if err != nil {
+				log.Fatalf("can not find webhook, error: %+v", err)
+			}
+			if err = tabl.DeleteWebhook(string(webhook.Id)); err != nil {
+				log.Fatalf("can not delete webhook, error: %+v", err)
+			}
+		}
+	},
+}
+
+// webhooksListenCmd represents the webhooks listen command
+var webhooksListenCmd = &cobra.Command{
+	Use:   "listen",
+	Short: "Receives the payloads of webhooks and prints them, optionally running a command for every event",
+	Long: `Receives the payloads of webhooks and prints them, optionally running a command for every event.
+The command runs with sh -c, gets the payload json on stdin and the environment variables
+TABLEAU_EVENT_TYPE, TABLEAU_RESOURCE, TABLEAU_RESOURCE_NAME, TABLEAU_RESOURCE_ID, TABLEAU_SITE_ID and TABLEAU_CREATED_AT.
+Tableau only posts to https urls, put the listener behind a tls terminating proxy.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		events := make(map[tableau.WebhookEvent]bool)
+		for _, name := range tablListenEvents {
+			event, err := parseWebhookEvent(name)
+			if err != nil {
+				log.Fatalf("can not listen, error: %+v", err)
+			}
+			events[event] = true
+		}
+
+		http.Handle(tablListenPath, tableau.WebhookHandler(func(payload tableau.WebhookPayload) error {
+			if len(events) > 0 && !events[payload.EventType] {
+				return nil
+			}
+			fmt.Println(payload)
+			if tablListenExec == "" {
+				return nil
+			}
+			return runWebhookCommand(tablListenExec, payload)
+		}))
+		log.Printf("listening for webhook payloads on %s%s", tablListenAddress, tablListenPath)
+		log.Fatal(http.ListenAndServe(tablListenAddress, nil))
+	},
+}
+
+// parseWebhookEvent returns the webhook event with the given name, ignoring case
+func parseWebhookEvent(name string) (tableau.WebhookEvent, error) {
+	names := []string{}
+	for _, event := range tableau.WebhookEvents {
+		if strings.EqualFold(string(event), name) {
+			return event, nil
+		}
+		names = append(names, string(event))
+	}
+	return "", fmt.Errorf("unknown webhook event '%s', expecting one of %s", name, strings.Join(names, ", "))
+}
+
+// runWebhookCommand runs command for a webhook payload, cfr webhooksListenCmd
+func runWebhookCommand(command string, payload tableau.WebhookPayload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrapf(err, "can not json marshal payload")
+	}
+	run := exec.Command("sh", "-c", command)
+	run.Stdin = strings.NewReader(string(body))
+	run.Stdout = os.Stdout
+	run.Stderr = os.Stderr
+	run.Env = append(os.Environ(),
+		"TABLEAU_EVENT_TYPE="+string(payload.EventType),
+		"TABLEAU_RESOURCE="+payload.Resource,
+		"TABLEAU_RESOURCE_NAME="+payload.ResourceName,
+		"TABLEAU_RESOURCE_ID="+payload.ResourceID,
+		"TABLEAU_SITE_ID="+payload.SiteID,
+		"TABLEAU_CREATED_AT="+payload.CreatedAt.Format(time.RFC3339),
+	)
+	if err = run.Run(); err != nil {
+		log.Printf("command for %s failed, error: %v", payload, err)
+		return errors.Wrapf(err, "can not run command for event %s", payload.EventType)
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(webhooksCmd)
+
+	webhooksCmd.AddCommand(webhooksListCmd)
+	webhooksCmd.AddCommand(webhooksCreateCmd)
+	webhooksCmd.AddCommand(webhooksTestCmd)
+	webhooksCmd.AddCommand(webhooksDeleteCmd)
+	webhooksCmd.AddCommand(webhooksListenCmd)
+
+	// listen does not talk to tableau, only the other subcommands sign in
+	for _, cmd := range []*cobra.Command{webhooksListCmd, webhooksCreateCmd, webhooksTestCmd, webhooksDeleteCmd} {
+		addSigninFlags(cmd)
+	}
+
+	webhooksCreateCmd.Flags().StringVar(&tablWebhookEvent, "event", "", "event triggering the webhook, e.g. WorkbookRefreshFailed or DatasourceUpdated")
+	webhooksCreateCmd.MarkFlagRequired("event")
+	webhooksCreateCmd.Flags().StringVar(&tablWebhookURL, "destination", "", "https url the payloads are posted to")
+	webhooksCreateCmd.MarkFlagRequired("destination")
+
+	webhooksListenCmd.Flags().StringVar(&tablListenAddress, "addr", ":8080", "address to listen on")
+	webhooksListenCmd.Flags().StringVar(&tablListenPath, "path", "/", "url path receiving the payloads")
+	webhooksListenCmd.Flags().StringSliceVar(&tablListenEvents, "event", []string{}, "only handle these events, default all")
+	webhooksListenCmd.Flags().StringVar(&tablListenExec, "exec", "", "command to run for every event")
+}
@@ -0,0 +1,201 @@
+package tableau
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// catalog asset kinds, as used in rest api urls
+const (
+	KindDatabase ContentKind = "databases"
+	KindTable    ContentKind = "tables"
+)
+
+// data quality warning types
+const (
+	WarningDeprecated       = "Deprecated"
+	WarningWarning          = "Warning"
+	WarningStaleData        = "Stale data"
+	WarningUnderMaintenance = "Under maintenance"
+	WarningSensitiveData    = "Sensitive data"
+)
+
+// AssetUpdate changes the description, certification and contact of a database or table, nil fields are left alone
+type AssetUpdate struct {
+	Description       *string
+	IsCertified       *bool
+	CertificationNote *string
+	// ContactID is the id of the user to contact about the asset, empty leaves the contact alone
+	ContactID string
+}
+
+// payload returns the tsRequest updating an asset of kind
+func (update AssetUpdate) payload(kind ContentKind) string {
+	attributes := ""
+	if update.Description != nil {
+		attributes += fmt.Sprintf(` description="%s"`, xmlEscape(*update.Description))
+	}
+	if update.IsCertified != nil {
+		attributes += fmt.Sprintf(` isCertified="%t"`, *update.IsCertified)
+	}
+	if update.CertificationNote != nil {
+		attributes += fmt.Sprintf(` certificationNote="%s"`, xmlEscape(*update.CertificationNote))
+	}
+	contact := ""
+	if update.ContactID != "" {
+		contact = fmt.Sprintf(`<contact id="%s" />`, update.ContactID)
+	}
+	return fmt.Sprintf(`<tsRequest><%s%s>%s</%s></tsRequest>`, kind.element(), attributes, contact, kind.element())
+}
+
+// ListDatabases returns the database assets of the current site matching the (optional) filter expression
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_metadata.htm#query_databases
+func (tabl *TabGo) ListDatabases(filter string) ([]DatabaseType, error) {
+	databases := []DatabaseType{}
+	err := tabl.forEachPage(tabl.listURI("databases", filter), func(tsResponse TsResponse) int {
+		databases = append(databases, tsResponse.Databases.Database...)
+		return len(tsResponse.Databases.Database)
+	})
+	if err != nil {
+		return databases, errors.Wrapf(err, "can not list databases")
+	}
+	return databases, nil
+}
+
+// QueryDatabase returns a database asset, including its contact and certifier
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_metadata.htm#query_database
+func (tabl *TabGo) QueryDatabase(databaseID string) (DatabaseType, error) {
+	tsResponse, err := tabl.doTsRequest("GET", fmt.Sprintf("%s/databases/%s", tabl.SiteURL(), databaseID), "")
+	if err != nil {
+		return tsResponse.Database, errors.Wrapf(err, "can not query database '%s'", databaseID)
+	}
+	return tsResponse.Database, nil
+}
+
+// UpdateDatabase changes the description, certification and/or contact of a database asset
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_metadata.htm#update_database
+func (tabl *TabGo) UpdateDatabase(databaseID string, update AssetUpdate) (DatabaseType, error) {
+	tsResponse, err := tabl.doTsRequest("PUT", fmt.Sprintf("%s/databases/%s", tabl.SiteURL(), databaseID), update.payload(KindDatabase))
+	if err != nil {
+		return tsResponse.Database, errors.Wrapf(err, "can not update database '%s'", databaseID)
+	}
+	return tsResponse.Database, nil
+}
+
+// ListTables returns the table assets of the current site matching the (optional) filter expression
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_metadata.htm#query_tables
+func (tabl *TabGo) ListTables(filter string) ([]TableType, error) {
+	tables := []TableType{}
+	err := tabl.forEachPage(tabl.listURI("tables", filter), func(tsResponse TsResponse) int {
+		tables = append(tables, tsResponse.Tables.Table...)
+		return len(tsResponse.Tables.Table)
+	})
+	if err != nil {
+		return tables, errors.Wrapf(err, "can not list tables")
+	}
+	return tables, nil
+}
+
+// QueryTable returns a table asset, including its contact and certifier
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_metadata.htm#query_table
+func (tabl *TabGo) QueryTable(tableID string) (TableType, error) {
+	tsResponse, err := tabl.doTsRequest("GET", fmt.Sprintf("%s/tables/%s", tabl.SiteURL(), tableID), "")
+	if err != nil {
+		return tsResponse.Table, errors.Wrapf(err, "can not query table '%s'", tableID)
+	}
+	return tsResponse.Table, nil
+}
+
+// UpdateTable changes the description, certification and/or contact of a table asset
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_metadata.htm#update_table
+func (tabl *TabGo) UpdateTable(tableID string, update AssetUpdate) (TableType, error) {
+	tsResponse, err := tabl.doTsRequest("PUT", fmt.Sprintf("%s/tables/%s", tabl.SiteURL(), tableID), update.payload(KindTable))
+	if err != nil {
+		return tsResponse.Table, errors.Wrapf(err, "can not update table '%s'", tableID)
+	}
+	return tsResponse.Table, nil
+}
+
+// ListTableColumns returns the columns of a table asset
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_metadata.htm#query_columns
+func (tabl *TabGo) ListTableColumns(tableID string) ([]ColumnType, error) {
+	columns := []ColumnType{}
+	err := tabl.forEachPage(fmt.Sprintf("%s/tables/%s/columns", tabl.SiteURL(), tableID), func(tsResponse TsResponse) int {
+		columns = append(columns, tsResponse.Columns.Column...)
+		return len(tsResponse.Columns.Column)
+	})
+	if err != nil {
+		return columns, errors.Wrapf(err, "can not list columns of table '%s'", tableID)
+	}
+	return columns, nil
+}
+
+// UpdateColumnDescription changes the description of a column of a table asset
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_metadata.htm#update_column
+func (tabl *TabGo) UpdateColumnDescription(tableID, columnID, description string) (ColumnType, error) {
+	tsResponse, err := tabl.doTsRequest("PUT", fmt.Sprintf("%s/tables/%s/columns/%s", tabl.SiteURL(), tableID, columnID),
+		fmt.Sprintf(`<tsRequest><column description="%s" /></tsRequest>`, xmlEscape(description)))
+	if err != nil {
+		return tsResponse.Column, errors.Wrapf(err, "can not update column '%s' of table '%s'", columnID, tableID)
+	}
+	return tsResponse.Column, nil
+}
+
+// ListDataQualityWarnings returns the data quality warnings of a database, table, datasource or flow
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_metadata.htm#query_dqws
+func (tabl *TabGo) ListDataQualityWarnings(kind ContentKind, contentID string) ([]DataQualityWarningType, error) {
+	tsResponse, err := tabl.doTsRequest("GET", fmt.Sprintf("%s/dataQualityWarnings/%s/%s", tabl.SiteURL(), kind.element(), contentID), "")
+	if err != nil {
+		return tsResponse.DataQualityWarningList.DataQualityWarning,
+			errors.Wrapf(err, "can not list data quality warnings of %s '%s'", kind.element(), contentID)
+	}
+	return tsResponse.DataQualityWarningList.DataQualityWarning, nil
+}
+
+// QueryDataQualityWarning returns a data quality warning
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_metadata.htm#query_dqw_by_id
+func (tabl *TabGo) QueryDataQualityWarning(warningID string) (DataQualityWarningType, error) {
+	tsResponse, err := tabl.doTsRequest("GET", fmt.Sprintf("%s/dataQualityWarnings/%s", tabl.SiteURL(), warningID), "")
+	if err != nil {
+		return tsResponse.DataQualityWarning, errors.Wrapf(err, "can not query data quality warning '%s'", warningID)
+	}
+	return tsResponse.DataQualityWarning, nil
+}
+
+// CreateDataQualityWarning adds a warning of the given type (e.g. WarningDeprecated), message and activity
+// to a database, table, datasource or flow
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_metadata.htm#add_dqw
+func (tabl *TabGo) CreateDataQualityWarning(kind ContentKind, contentID string, warning DataQualityWarningType) (DataQualityWarningType, error) {
+	tsResponse, err := tabl.doTsRequest("POST", fmt.Sprintf("%s/dataQualityWarnings/%s/%s", tabl.SiteURL(), kind.element(), contentID),
+		fmt.Sprintf(`<tsRequest><dataQualityWarning%s /></tsRequest>`, dataQualityWarningAttributes(warning)))
+	if err != nil {
+		return tsResponse.DataQualityWarning, errors.Wrapf(err, "can not add data quality warning to %s '%s'", kind.element(), contentID)
+	}
+	return tsResponse.DataQualityWarning, nil
+}
+
+// UpdateDataQualityWarning changes the type, message and activity of a data quality warning
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_metadata.htm#update_dqw
+func (tabl *TabGo) UpdateDataQualityWarning(warningID string, warning DataQualityWarningType) (DataQualityWarningType, error) {
+	tsResponse, err := tabl.doTsRequest("PUT", fmt.Sprintf("%s/dataQualityWarnings/%s", tabl.SiteURL(), warningID),
+		fmt.Sprintf(`<tsRequest><dataQualityWarning%s /></tsRequest>`, dataQualityWarningAttributes(warning)))
+	if err != nil {
+		return tsResponse.DataQualityWarning, errors.Wrapf(err, "can not update data quality warning '%s'", warningID)
+	}
+	return tsResponse.DataQualityWarning, nil
+}
+
+// DeleteDataQualityWarning deletes a data quality warning
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_metadata.htm#delete_dqw_by_id
+func (tabl *TabGo) DeleteDataQualityWarning(warningID string) error {
+	_, err := tabl.doRequest("DELETE", fmt.Sprintf("%s/dataQualityWarnings/%s", tabl.SiteURL(), warningID), "")
+	if err != nil {
+		return errors.Wrapf(err, "can not delete data quality warning '%s'", warningID)
+	}
+	return nil
+}
+
+func dataQualityWarningAttributes(warning DataQualityWarningType) string {
+	return fmt.Sprintf(` type="%s" isActive="%t" message="%s"`, xmlEscape(warning.Type), warning.IsActive, xmlEscape(warning.Message))
+}
@@ -0,0 +1,28 @@
+package tableau
+
+import "testing"
+
+func TestAssetUpdatePayload(t *testing.T) {
+	description, empty, note := "Orders of <all> regions & years", "", "Reviewed by the data stewards"
+	certified := true
+
+	tests := []struct {
+		name    string
+		update  AssetUpdate
+		kind    ContentKind
+		payload string
+	}{
+		{"nothing", AssetUpdate{}, KindTable, `<tsRequest><table></table></tsRequest>`},
+		{"escaped description", AssetUpdate{Description: &description}, KindDatabase,
+			`<tsRequest><database description="Orders of &lt;all&gt; regions &amp; years"></database></tsRequest>`},
+		{"cleared description", AssetUpdate{Description: &empty}, KindTable, `<tsRequest><table description=""></table></tsRequest>`},
+		{"certification", AssetUpdate{IsCertified: &certified, CertificationNote: &note}, KindTable,
+			`<tsRequest><table isCertified="true" certificationNote="Reviewed by the data stewards"></table></tsRequest>`},
+		{"contact", AssetUpdate{ContactID: "u1"}, KindDatabase, `<tsRequest><database><contact id="u1" /></database></tsRequest>`},
+	}
+	for _, test := range tests {
+		if payload := test.update.payload(test.kind); payload != test.payload {
+			t.Errorf("%s: payload = %s, expecting %s", test.name, payload, test.payload)
+		}
+	}
+}
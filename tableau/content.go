@@ -0,0 +1,279 @@
+package tableau
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// listURI returns the uri of a site resource list, optionally filtered with a tableau filter expression
+// e.g. "ownerName:eq:jdoe"
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_concepts_filtering_and_sorting.htm
+func (tabl *TabGo) listURI(resource, filter string) string {
+	uri := fmt.Sprintf("%s/%s", tabl.SiteURL(), resource)
+	if filter != "" {
+		uri += "?filter=" + url.QueryEscape(filter)
+	}
+	return uri
+}
+
+// ListWorkbooks returns all workbooks on the current site matching the (optional) filter expression
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_workbooksviews.htm#query_workbooks_for_site
+func (tabl *TabGo) ListWorkbooks(filter string) ([]WorkbookType, error) {
+	workbooks := []WorkbookType{}
+	err := tabl.forEachPage(tabl.listURI("workbooks", filter), func(tsResponse TsResponse) int {
+		workbooks = append(workbooks, tsResponse.Workbooks.Workbook...)
+		return len(tsResponse.Workbooks.Workbook)
+	})
+	if err != nil {
+		return workbooks, errors.Wrapf(err, "can not list workbooks")
+	}
+	return workbooks, nil
+}
+
+// QueryWorkbook returns a workbook, including its views and tags
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_workbooksviews.htm#query_workbook
+func (tabl *TabGo) QueryWorkbook(workbookID string) (WorkbookType, error) {
+	tsResponse, err := tabl.doTsRequest("GET", fmt.Sprintf("%s/workbooks/%s", tabl.SiteURL(), workbookID), "")
+	if err != nil {
+		return tsResponse.Workbook, errors.Wrapf(err, "can not query workbook '%s'", workbookID)
+	}
+	return tsResponse.Workbook, nil
+}
+
+// ListDatasources returns all published datasources on the current site matching the (optional) filter expression
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_datasources.htm#query_data_sources
+func (tabl *TabGo) ListDatasources(filter string) ([]DataSourceType, error) {
+	datasources := []DataSourceType{}
+	err := tabl.forEachPage(tabl.listURI("datasources", filter), func(tsResponse TsResponse) int {
+		datasources = append(datasources, tsResponse.Datasources.Datasource...)
+		return len(tsResponse.Datasources.Datasource)
+	})
+	if err != nil {
+		return datasources, errors.Wrapf(err, "can not list datasources")
+	}
+	return datasources, nil
+}
+
+// ListFlows returns all flows on the current site matching the (optional) filter expression
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_flow.htm#query_flows_for_site
+func (tabl *TabGo) ListFlows(filter string) ([]FlowType, error) {
+	flows := []FlowType{}
+	err := tabl.forEachPage(tabl.listURI("flows", filter), func(tsResponse TsResponse) int {
+		flows = append(flows, tsResponse.Flows.Flow...)
+		return len(tsResponse.Flows.Flow)
+	})
+	if err != nil {
+		return flows, errors.Wrapf(err, "can not list flows")
+	}
+	return flows, nil
+}
+
+// element returns the name of the tsRequest element of a resource of kind, e.g. "workbook" for KindWorkbook
+func (kind ContentKind) element() string {
+	return strings.TrimSuffix(string(kind), "s")
+}
+
+// UpdateContentOwner makes a user of the current site the owner of a workbook, datasource, flow or project
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_workbooksviews.htm#update_workbook
+func (tabl *TabGo) UpdateContentOwner(kind ContentKind, id, ownerID string) error {
+	var err error
+	if kind == KindProject {
+		_, err = tabl.UpdateProjectOwner(id, ownerID)
+	} else {
+		err = tabl.updateContent(kind, id, "", fmt.Sprintf(`<owner id="%s" />`, ownerID))
+	}
+	if err != nil {
+		return errors.Wrapf(err, "can not change owner of %s '%s'", kind.element(), id)
+	}
+	return nil
+}
+
+// UpdateDatasourceCertification certifies a published datasource with a note explaining the certification,
+// or clears its certification (and note) when certified is false
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_datasources.htm#update_data_source
+func (tabl *TabGo) UpdateDatasourceCertification(datasourceID string, certified bool, note string) error {
+	if !certified {
+		note = ""
+	}
+	err := tabl.updateContent(KindDatasource, datasourceID, fmt.Sprintf(` isCertified="%t" certificationNote="%s"`, certified, xmlEscape(note)), "")
+	if err != nil {
+		return errors.Wrapf(err, "can not update certification of datasource '%s'", datasourceID)
+	}
+	return nil
+}
+
+// updateContent sends a PUT to a workbook, datasource or flow with the given attributes and child elements
+func (tabl *TabGo) updateContent(kind ContentKind, id, attributes, elements string) error {
+	_, err := tabl.doRequest("PUT", fmt.Sprintf("%s/%s/%s", tabl.SiteURL(), kind, id),
+		fmt.Sprintf(`<tsRequest><%s%s>%s</%s></tsRequest>`, kind.element(), attributes, elements, kind.element()))
+	return err
+}
+
+// splitContentPath splits the path of a workbook, datasource or flow ("Parent/Child/Sales", cfr SplitProjectPath)
+// into the project holding it and its name
+func (tabl *TabGo) splitContentPath(contentPath string) (*ProjectNode, string, error) {
+	names := SplitProjectPath(contentPath)
+	if len(names) < 2 {
+		return nil, "", fmt.Errorf("invalid content path '%s', expecting <project path>/<name>", contentPath)
+	}
+	projectPath := JoinProjectPath(names[:len(names)-1]...)
+	project, err := tabl.FindProject(projectPath)
+	if err != nil {
+		return nil, "", err
+	}
+	if project == nil {
+		return nil, "", fmt.Errorf("project '%s' does not exist on site '%s'", projectPath, tabl.CurrentSiteName)
+	}
+	return project, names[len(names)-1], nil
+}
+
+// FindWorkbook returns the workbook at contentPath, the path of its project followed by its name (e.g. "Finance/Reports/Sales")
+func (tabl *TabGo) FindWorkbook(contentPath string) (WorkbookType, error) {
+	project, name, err := tabl.splitContentPath(contentPath)
+	if err != nil {
+		return WorkbookType{}, err
+	}
+	workbooks, err := tabl.ListWorkbooks("name:eq:" + name)
+	if err != nil {
+		return WorkbookType{}, err
+	}
+	for _, workbook := range workbooks {
+		if workbook.Name == name && workbook.Project.Id == project.Project.Id {
+			return workbook, nil
+		}
+	}
+	return WorkbookType{}, fmt.Errorf("no workbook '%s' found on site '%s'", contentPath, tabl.CurrentSiteName)
+}
+
+// FindDatasource returns the published datasource at contentPath, the path of its project followed by its name
+func (tabl *TabGo) FindDatasource(contentPath string) (DataSourceType, error) {
+	project, name, err := tabl.splitContentPath(contentPath)
+	if err != nil {
+		return DataSourceType{}, err
+	}
+	datasources, err := tabl.ListDatasources("name:eq:" + name)
+	if err != nil {
+		return DataSourceType{}, err
+	}
+	for _, datasource := range datasources {
+		if datasource.Name == name && datasource.Project.Id == project.Project.Id {
+			return datasource, nil
+		}
+	}
+	return DataSourceType{}, fmt.Errorf("no datasource '%s' found on site '%s'", contentPath, tabl.CurrentSiteName)
+}
+
+// FindFlow returns the flow at contentPath, the path of its project followed by its name
+func (tabl *TabGo) FindFlow(contentPath string) (FlowType, error) {
+	project, name, err := tabl.splitContentPath(contentPath)
+	if err != nil {
+		return FlowType{}, err
+	}
+	flows, err := tabl.ListFlows("name:eq:" + name)
+	if err != nil {
+		return FlowType{}, err
+	}
+	for _, flow := range flows {
+		if flow.Name == name && flow.Project.Id == project.Project.Id {
+			return flow, nil
+		}
+	}
+	return FlowType{}, fmt.Errorf("no flow '%s' found on site '%s'", contentPath, tabl.CurrentSiteName)
+}
+
+// Content is a workbook, datasource, view or flow of the current site
+type Content struct {
+	Kind ContentKind
+	Id   ResourceIdType
+	Name string
+	// Path is the path of the project holding the content followed by its name (e.g. "Finance/Reports/Sales"),
+	// for a view the path of its workbook followed by its name
+	Path  string
+	Owner UserType
+	Tags  []string
+}
+
+func (content Content) String() string {
+	return fmt.Sprintf("%s %s", content.Kind.element(), content.Path)
+}
+
+// ContentKinds are the kinds of content ListContent returns
+var ContentKinds = []ContentKind{KindWorkbook, KindDatasource, KindView, KindFlow}
+
+// ParseContentKind returns the content kind with the given name, singular or plural and ignoring case, e.g. "Workbook"
+func ParseContentKind(name string) (ContentKind, error) {
+	for _, kind := range ContentKinds {
+		if strings.EqualFold(name, string(kind)) || strings.EqualFold(name, kind.element()) {
+			return kind, nil
+		}
+	}
+	return "", fmt.Errorf("unknown content kind '%s', expecting workbook, datasource, view or flow", name)
+}
+
+// ListContent returns the workbooks, datasources, views or flows of the current site matching the (optional) filter expression,
+// with their path
+func (tabl *TabGo) ListContent(kind ContentKind, filter string) ([]Content, error) {
+	contents := []Content{}
+	if _, err := tabl.cachedProjectTree(); err != nil {
+		return contents, err
+	}
+	projectPath := func(project ProjectType) string {
+		if node := tabl.cachedProjectNode(project.Id); node != nil {
+			return node.Path()
+		}
+		return ""
+	}
+	add := func(id ResourceIdType, name, parentPath string, owner UserType, tags TagListType) {
+		contents = append(contents, Content{Kind: kind, Id: id, Name: name, Path: parentPath + "/" + EscapeProjectName(name),
+			Owner: owner, Tags: tagLabels(tags)})
+	}
+
+	switch kind {
+	case KindWorkbook:
+		workbooks, err := tabl.ListWorkbooks(filter)
+		if err != nil {
+			return contents, err
+		}
+		for _, workbook := range workbooks {
+			add(workbook.Id, workbook.Name, projectPath(workbook.Project), workbook.Owner, workbook.Tags)
+		}
+	case KindDatasource:
+		datasources, err := tabl.ListDatasources(filter)
+		if err != nil {
+			return contents, err
+		}
+		for _, datasource := range datasources {
+			add(datasource.Id, datasource.Name, projectPath(datasource.Project), datasource.Owner, datasource.Tags)
+		}
+	case KindView:
+		views, err := tabl.ListViews(filter)
+		if err != nil {
+			return contents, err
+		}
+		workbooks, err := tabl.ListWorkbooks("")
+		if err != nil {
+			return contents, err
+		}
+		workbookPaths := make(map[ResourceIdType]string)
+		for _, workbook := range workbooks {
+			workbookPaths[workbook.Id] = projectPath(workbook.Project) + "/" + EscapeProjectName(workbook.Name)
+		}
+		for _, view := range views {
+			add(view.Id, view.Name, workbookPaths[view.Workbook.Id], view.Owner, view.Tags)
+		}
+	case KindFlow:
+		flows, err := tabl.ListFlows(filter)
+		if err != nil {
+			return contents, err
+		}
+		for _, flow := range flows {
+			add(flow.Id, flow.Name, projectPath(flow.Project), flow.Owner, flow.Tags)
+		}
+	default:
+		return contents, fmt.Errorf("can not list content of kind '%s'", kind)
+	}
+	return contents, nil
+}
@@ -0,0 +1,95 @@
+package tableau
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// ListDataAlerts returns the data driven alerts of the current site matching the (optional) filter expression
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_dataalerts.htm#query_data_driven_alerts
+func (tabl *TabGo) ListDataAlerts(filter string) ([]DataAlertType, error) {
+	alerts := []DataAlertType{}
+	err := tabl.forEachPage(tabl.listURI("dataAlerts", filter), func(tsResponse TsResponse) int {
+		alerts = append(alerts, tsResponse.DataAlerts.DataAlert...)
+		return len(tsResponse.DataAlerts.DataAlert)
+	})
+	if err != nil {
+		return alerts, errors.Wrapf(err, "can not list data alerts")
+	}
+	return alerts, nil
+}
+
+// QueryDataAlert returns a data driven alert, including its owner, view and recipients
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_dataalerts.htm#query_data_driven_alert_details
+func (tabl *TabGo) QueryDataAlert(alertID string) (DataAlertType, error) {
+	tsResponse, err := tabl.doTsRequest("GET", fmt.Sprintf("%s/dataAlerts/%s", tabl.SiteURL(), alertID), "")
+	if err != nil {
+		return tsResponse.DataAlert, errors.Wrapf(err, "can not query data alert '%s'", alertID)
+	}
+	return tsResponse.DataAlert, nil
+}
+
+// AddDataAlertRecipient adds a user to the recipients of a data driven alert
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_dataalerts.htm#add_user_to_data_driven_alert
+func (tabl *TabGo) AddDataAlertRecipient(alertID, userID string) error {
+	_, err := tabl.doRequest("POST", fmt.Sprintf("%s/dataAlerts/%s/users", tabl.SiteURL(), alertID),
+		fmt.Sprintf(`<tsRequest><user id="%s" /></tsRequest>`, userID))
+	if err != nil {
+		return errors.Wrapf(err, "can not add user '%s' to data alert '%s'", userID, alertID)
+	}
+	return nil
+}
+
+// RemoveDataAlertRecipient removes a user from the recipients of a data driven alert
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_dataalerts.htm#delete_user_from_data_driven_alert
+func (tabl *TabGo) RemoveDataAlertRecipient(alertID, userID string) error {
+	_, err := tabl.doRequest("DELETE", fmt.Sprintf("%s/dataAlerts/%s/users/%s", tabl.SiteURL(), alertID, userID), "")
+	if err != nil {
+		return errors.Wrapf(err, "can not remove user '%s' from data alert '%s'", userID, alertID)
+	}
+	return nil
+}
+
+// DeleteDataAlert deletes a data driven alert
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_dataalerts.htm#delete_data_driven_alert
+func (tabl *TabGo) DeleteDataAlert(alertID string) error {
+	_, err := tabl.doRequest("DELETE", fmt.Sprintf("%s/dataAlerts/%s", tabl.SiteURL(), alertID), "")
+	if err != nil {
+		return errors.Wrapf(err, "can not delete data alert '%s'", alertID)
+	}
+	return nil
+}
+
+// ListOrphanedDataAlerts returns the data driven alerts of views that no longer exist,
+// e.g. because they were removed when their workbook was republished.
+// An alert listed without its view is queried for it, and skipped when its view is still unknown.
+func (tabl *TabGo) ListOrphanedDataAlerts() ([]DataAlertType, error) {
+	orphans := []DataAlertType{}
+	alerts, err := tabl.ListDataAlerts("")
+	if err != nil {
+		return orphans, err
+	}
+	views, err := tabl.ListViews("")
+	if err != nil {
+		return orphans, err
+	}
+	existing := make(map[ResourceIdType]bool)
+	for _, view := range views {
+		existing[view.Id] = true
+	}
+	for _, alert := range alerts {
+		if alert.View.Id == "" {
+			if alert, err = tabl.QueryDataAlert(string(alert.Id)); err != nil {
+				return orphans, err
+			}
+			if alert.View.Id == "" {
+				continue
+			}
+		}
+		if !existing[alert.View.Id] {
+			orphans = append(orphans, alert)
+		}
+	}
+	return orphans, nil
+}
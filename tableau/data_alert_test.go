@@ -0,0 +1,31 @@
+package tableau
+
+import "testing"
+
+func TestListOrphanedDataAlerts(t *testing.T) {
+	tabl, cleanup := newTestServer(t, map[string]string{
+		"/dataAlerts": `<dataAlerts>
+			<dataAlert id="a1" subject="Existing view"><view id="v1" /></dataAlert>
+			<dataAlert id="a2" subject="Removed view"><view id="v2" /></dataAlert>
+			<dataAlert id="a3" subject="Listed without view" />
+			<dataAlert id="a4" subject="Unknown view" />
+		</dataAlerts>`,
+		"/dataAlerts/a3": `<dataAlert id="a3" subject="Listed without view"><view id="v3" /></dataAlert>`,
+		"/dataAlerts/a4": `<dataAlert id="a4" subject="Unknown view" />`,
+		"/views":         `<views><view id="v1" name="Overview" /></views>`,
+	})
+	defer cleanup()
+
+	orphans, err := tabl.ListOrphanedDataAlerts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(orphans) != 2 {
+		t.Fatalf("orphans = %+v, expecting a2 and a3", orphans)
+	}
+	for i, expected := range []struct{ alert, view ResourceIdType }{{"a2", "v2"}, {"a3", "v3"}} {
+		if orphans[i].Id != expected.alert || orphans[i].View.Id != expected.view {
+			t.Errorf("orphan %d = %s of view %s, expecting %s of view %s", i, orphans[i].Id, orphans[i].View.Id, expected.alert, expected.view)
+		}
+	}
+}
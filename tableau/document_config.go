@@ -0,0 +1,407 @@
+package tableau
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// DocumentConfigVersion is the version of the document config format read by this version of tabgo
+const DocumentConfigVersion = 1
+
+// RefreshType is the kind of extract refresh of a scheduled document
+type RefreshType string
+
+// refresh types
+const (
+	RefreshTypeFull        RefreshType = "Full"
+	RefreshTypeIncremental RefreshType = "Incremental"
+)
+
+// documentConfigSuffixes are the suffixes of the config file of a document, in order of preference,
+// Sales.tds is configured by Sales.tds.tabgo.yaml, Sales.tds.tabgo.yml or Sales.tds.tabgo.json
+var documentConfigSuffixes = []string{".tabgo.yaml", ".tabgo.yml", ".tabgo.json"}
+
+// legacyDocumentConfigSuffix is the suffix of the config file of a document before versioned configs,
+// cfr legacyDocumentConfig
+const legacyDocumentConfigSuffix = ".json"
+
+// DocumentConfig is the publish config of a workbook or datasource, read from a file next to the document.
+// The file is yaml or json (json is yaml too), unknown keys are an error.
+// Example yaml (Sales.tds.tabgo.yaml):
+//
+//	version: 1
+//	project: Finance/Sales
+//	tags: [finance, sales]
+//	extract:
+//	  encrypt: true
+//	schedule:
+//	  name: Nightly
+//	  refreshType: Incremental
+//	permissions:
+//	  - group: Analysts
+//	    capabilities: {Read: Allow, ExportData: Deny}
+//	connections:
+//	  Sales DWH:
+//	    serverAddress: dwh-test.example.com
+//	    userName: sales_reader
+//	certification:
+//	  certified: true
+//	  note: Reviewed by the finance data stewards
+type DocumentConfig struct {
+	Version int `yaml:"version"`
+	// Project is the project path the document is published to when no project is given to PublishDocument
+	Project     string                        `yaml:"project,omitempty"`
+	Tags        []string                      `yaml:"tags,omitempty"`
+	Extract     *ExtractConfig                `yaml:"extract,omitempty"`
+	Schedule    *ScheduleConfig               `yaml:"schedule,omitempty"`
+	Permissions []PermissionRule              `yaml:"permissions,omitempty"`
+	Connections map[string]ConnectionOverride `yaml:"connections,omitempty"`
+	// Certification is (re)applied after every publish, so a republished datasource keeps its certification
+	Certification *CertificationConfig `yaml:"certification,omitempty"`
+}
+
+// ExtractConfig makes PublishDocument (re)create the extracts of a published document
+type ExtractConfig struct {
+	Encrypt bool `yaml:"encrypt,omitempty"`
+}
+
+// ScheduleConfig attaches a published document to an extract refresh schedule, refreshing it fully unless
+// the refresh type is Incremental
+type ScheduleConfig struct {
+	Name        string      `yaml:"name"`
+	RefreshType RefreshType `yaml:"refreshType,omitempty"`
+}
+
+// CertificationConfig certifies a published datasource, a certified datasource needs a note explaining the certification
+type CertificationConfig struct {
+	Certified bool   `yaml:"certified"`
+	Note      string `yaml:"note,omitempty"`
+}
+
+// ConnectionOverride replaces the non empty settings of the target connection with the same caption,
+// cfr ConnectionFinder
+type ConnectionOverride struct {
+	ServerAddress string `yaml:"serverAddress,omitempty"`
+	ServerPort    string `yaml:"serverPort,omitempty"`
+	UserName      string `yaml:"userName,omitempty"`
+	Password      string `yaml:"password,omitempty"`
+	DbName        string `yaml:"dbName,omitempty"`
+	Schema        string `yaml:"schema,omitempty"`
+}
+
+// legacyDocumentConfig is the unversioned <document>.json config of datasources,
+// e.g. {"ExtractDataSourceData":true,"EncryptData":false,"RefreshType":"Incremental","Schedule":"Nightly"}
+// It is json decoded as before versioned configs: keys are case insensitive and unknown keys are ignored.
+type legacyDocumentConfig struct {
+	ExtractDataSourceData bool
+	EncryptData           bool
+	RefreshType           RefreshType
+	Schedule              string
+}
+
+// FindDocumentConfig returns the path of the config file of a document, or "" when it has none.
+// It is an error when a document has more than one config file.
+func FindDocumentConfig(documentPath string) (string, error) {
+	found := []string{}
+	for _, suffix := range append(documentConfigSuffixes, legacyDocumentConfigSuffix) {
+		if fileExists(documentPath + suffix) {
+			found = append(found, documentPath+suffix)
+		}
+	}
+	if len(found) > 1 {
+		return "", fmt.Errorf("document %s has more than one config file: %s", documentPath, strings.Join(found, ", "))
+	}
+	if len(found) == 0 {
+		return "", nil
+	}
+	return found[0], nil
+}
+
+// LoadDocumentConfig reads and validates the config file of a document (cfr FindDocumentConfig),
+// found is false when the document has none
+func LoadDocumentConfig(documentPath string) (config DocumentConfig, found bool, err error) {
+	path, err := FindDocumentConfig(documentPath)
+	if err != nil || path == "" {
+		return config, false, err
+	}
+	config, err = ReadDocumentConfig(path)
+	return config, true, err
+}
+
+// ReadDocumentConfig reads and validates a document config file,
+// an unversioned <document>.json config is converted to the current version
+func ReadDocumentConfig(path string) (DocumentConfig, error) {
+	config := DocumentConfig{}
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return config, errors.Wrapf(err, "can not read %s", path)
+	}
+
+	if isLegacyDocumentConfig(path) {
+		legacy := legacyDocumentConfig{}
+		if err = json.Unmarshal(content, &legacy); err != nil {
+			return config, errors.Wrapf(err, "can not json decode %s", path)
+		}
+		log.Printf("%s is deprecated, move its settings to a versioned config, e.g. %s", path, strings.TrimSuffix(path, legacyDocumentConfigSuffix)+documentConfigSuffixes[0])
+		config = legacy.convert()
+	} else if err = yaml.UnmarshalStrict(content, &config); err != nil {
+		return config, errors.Wrapf(err, "can not decode %s", path)
+	}
+
+	if err = config.ValidateDocument(DocumentOfConfig(path)); err != nil {
+		return config, errors.Wrapf(err, "invalid document config %s", path)
+	}
+	return config, nil
+}
+
+// isLegacyDocumentConfig tells whether path is an unversioned <document>.json config
+func isLegacyDocumentConfig(path string) bool {
+	return strings.HasSuffix(path, legacyDocumentConfigSuffix) && IsDocument(strings.TrimSuffix(path, legacyDocumentConfigSuffix))
+}
+
+// IsDocumentConfig tells whether path is named like the config file of a document, cfr FindDocumentConfig
+func IsDocumentConfig(path string) bool {
+	for _, suffix := range documentConfigSuffixes {
+		if strings.HasSuffix(path, suffix) {
+			return true
+		}
+	}
+	return isLegacyDocumentConfig(path)
+}
+
+// DocumentOfConfig returns the path of the document configured by the config file at path,
+// path itself when it is not named like a config file
+func DocumentOfConfig(path string) string {
+	for _, suffix := range documentConfigSuffixes {
+		if strings.HasSuffix(path, suffix) {
+			return strings.TrimSuffix(path, suffix)
+		}
+	}
+	if isLegacyDocumentConfig(path) {
+		return strings.TrimSuffix(path, legacyDocumentConfigSuffix)
+	}
+	return path
+}
+
+// isWorkbook tells whether path is a workbook document
+func isWorkbook(path string) bool {
+	extension := filepath.Ext(path)
+	return extension == ".twb" || extension == ".twbx"
+}
+
+// IsDocument tells whether path is a document that can be published, cfr PublishDocument
+func IsDocument(path string) bool {
+	switch filepath.Ext(path) {
+	case ".tds", ".tdsx", ".twb", ".twbx":
+		return true
+	}
+	return false
+}
+
+func (legacy legacyDocumentConfig) convert() DocumentConfig {
+	config := DocumentConfig{Version: DocumentConfigVersion}
+	if legacy.ExtractDataSourceData {
+		config.Extract = &ExtractConfig{Encrypt: legacy.EncryptData}
+	}
+	if legacy.Schedule != "" {
+		config.Schedule = &ScheduleConfig{Name: legacy.Schedule, RefreshType: legacy.RefreshType}
+		// the legacy refresh type is case insensitive
+		for _, refreshType := range []RefreshType{RefreshTypeFull, RefreshTypeIncremental} {
+			if strings.EqualFold(string(legacy.RefreshType), string(refreshType)) {
+				config.Schedule.RefreshType = refreshType
+			}
+		}
+	}
+	return config
+}
+
+// Validate checks a document config without contacting tableau, it returns all problems found in a single error
+func (config DocumentConfig) Validate() error {
+	return config.ValidateDocument("")
+}
+
+// ValidateDocument checks a document config as the config of the document at documentPath, cfr Validate,
+// e.g. a workbook can not be certified. An empty documentPath skips the checks that depend on the document.
+func (config DocumentConfig) ValidateDocument(documentPath string) error {
+	problems := []string{}
+	switch {
+	case config.Version == 0:
+		problems = append(problems, fmt.Sprintf("version is missing, expecting %d", DocumentConfigVersion))
+	case config.Version != DocumentConfigVersion:
+		problems = append(problems, fmt.Sprintf("version %d is not supported, expecting %d", config.Version, DocumentConfigVersion))
+	}
+
+	if config.Project != "" && len(SplitProjectPath(config.Project)) == 0 {
+		problems = append(problems, fmt.Sprintf("invalid project path '%s'", config.Project))
+	}
+
+	for _, tag := range config.Tags {
+		if strings.TrimSpace(tag) == "" || strings.Contains(tag, ",") {
+			problems = append(problems, fmt.Sprintf("invalid tag '%s', expecting a non empty label without commas", tag))
+		}
+	}
+
+	if config.Schedule != nil {
+		if config.Schedule.Name == "" {
+			problems = append(problems, "schedule without name")
+		}
+		switch config.Schedule.RefreshType {
+		case "", RefreshTypeFull, RefreshTypeIncremental:
+		default:
+			problems = append(problems, fmt.Sprintf("invalid refreshType '%s', expecting %s or %s", config.Schedule.RefreshType, RefreshTypeFull, RefreshTypeIncremental))
+		}
+	}
+
+	for i, rule := range config.Permissions {
+		if (rule.Group == "") == (rule.User == "") {
+			problems = append(problems, fmt.Sprintf("permission rule %d: expecting either a group or a user", i+1))
+		}
+		if len(rule.Capabilities) == 0 {
+			problems = append(problems, fmt.Sprintf("permission rule %d: no capabilities", i+1))
+		}
+		for name, mode := range rule.Capabilities {
+			if mode != ModeAllow && mode != ModeDeny {
+				problems = append(problems, fmt.Sprintf("permission rule %d: invalid mode '%s' for capability %s, expecting %s or %s", i+1, mode, name, ModeAllow, ModeDeny))
+			}
+		}
+	}
+
+	for caption, override := range config.Connections {
+		if caption == "" {
+			problems = append(problems, "connection override without caption")
+		}
+		if override == (ConnectionOverride{}) {
+			problems = append(problems, fmt.Sprintf("connection override '%s' does not override anything", caption))
+		}
+	}
+
+	if config.Certification != nil && config.Certification.Certified && strings.TrimSpace(config.Certification.Note) == "" {
+		problems = append(problems, "certification without note, a certified datasource needs a note explaining the certification")
+	}
+	if config.Certification != nil && isWorkbook(documentPath) {
+		problems = append(problems, "certification of a workbook, only datasources can be certified")
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// ConnectionFinder returns a ConnectionFinder that applies the connection overrides of the config
+// to the connections found by finder, finder may be nil when the overrides are complete connections
+func (config DocumentConfig) ConnectionFinder(finder ConnectionFinder) ConnectionFinder {
+	if len(config.Connections) == 0 && finder != nil {
+		return finder
+	}
+	return overridingConnectionFinder{overrides: config.Connections, finder: finder}
+}
+
+type overridingConnectionFinder struct {
+	overrides map[string]ConnectionOverride
+	finder    ConnectionFinder
+}
+
+func (cf overridingConnectionFinder) FindConnection(caption string) (Connection, error) {
+	override, overridden := cf.overrides[caption]
+	connection := Connection{}
+	if cf.finder != nil {
+		var err error
+		if connection, err = cf.finder.FindConnection(caption); err != nil && !overridden {
+			return connection, err
+		}
+	} else if !overridden {
+		return connection, fmt.Errorf("no target connection found for caption '%s'", caption)
+	}
+
+	for target, value := range map[*string]string{
+		&connection.ServerAddress: override.ServerAddress,
+		&connection.ServerPort:    override.ServerPort,
+		&connection.UserName:      override.UserName,
+		&connection.PassWord:      override.Password,
+		&connection.DbName:        override.DbName,
+		&connection.Schema:        override.Schema,
+	} {
+		if value != "" {
+			*target = value
+		}
+	}
+	return connection, nil
+}
+
+// warnUncertifiedOverwrite warns when publishing datasource name to a project overwrites a certified datasource
+// while the document config has no certification, the republished datasource would silently lose its certification
+func (tabl *TabGo) warnUncertifiedOverwrite(name, projectID string, config DocumentConfig) {
+	if config.Certification != nil {
+		return
+	}
+	datasources, err := tabl.ListDatasources("name:eq:" + name)
+	if err != nil {
+		log.Printf("can not check whether datasource '%s' is certified: %v", name, err)
+		return
+	}
+	for _, datasource := range datasources {
+		if datasource.Name == name && string(datasource.Project.Id) == projectID && datasource.IsCertified {
+			log.Printf("warning: overwriting certified datasource '%s' (%s), its config has no certification, add one to keep it certified",
+				name, datasource.CertificationNote)
+		}
+	}
+}
+
+// applyDocumentConfig applies the extract, tags, schedule, permissions and certification of a document config
+// to a just published workbook or datasource
+func (tabl *TabGo) applyDocumentConfig(kind ContentKind, id, name string, config DocumentConfig) error {
+	if config.Extract != nil {
+		var err error
+		if kind == KindDatasource {
+			_ = tabl.DeleteExtractedDatasourceData(id)
+			err = tabl.ExtractDatasourceData(id, config.Extract.Encrypt)
+		} else {
+			_ = tabl.DeleteWorkbookExtracts(id)
+			err = tabl.ExtractWorkbookData(id, config.Extract.Encrypt)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "can not extract data for %s '%s'", kind.element(), name)
+		}
+	}
+
+	if len(config.Tags) > 0 {
+		if _, err := tabl.AddTags(kind, id, config.Tags); err != nil {
+			return err
+		}
+	}
+
+	if config.Schedule != nil {
+		err := tabl.EnsureExtractRefreshTask(kind, id, config.Schedule.Name, config.Schedule.RefreshType == RefreshTypeIncremental)
+		if err != nil {
+			return errors.Wrapf(err, "can not schedule extract refresh for %s '%s'", kind.element(), name)
+		}
+	}
+
+	if len(config.Permissions) > 0 {
+		grantees, err := tabl.ResolvePermissionRules(config.Permissions)
+		if err != nil {
+			return errors.Wrapf(err, "can not resolve permissions for %s '%s'", kind.element(), name)
+		}
+		if _, err = tabl.AddPermissions(kind, id, grantees); err != nil {
+			return err
+		}
+	}
+
+	if config.Certification != nil {
+		if kind != KindDatasource {
+			return fmt.Errorf("can not certify %s '%s', only datasources can be certified", kind.element(), name)
+		}
+		if err := tabl.UpdateDatasourceCertification(id, config.Certification.Certified, config.Certification.Note); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,261 @@
+package tableau
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDocumentConfigValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		config DocumentConfig
+		// problem is part of the expected error, "" when the config is valid
+		problem string
+	}{
+		{"minimal", DocumentConfig{Version: 1}, ""},
+		{"complete", DocumentConfig{
+			Version:     1,
+			Project:     "Finance/Sales",
+			Tags:        []string{"finance", "sales"},
+			Extract:     &ExtractConfig{Encrypt: true},
+			Schedule:    &ScheduleConfig{Name: "Nightly", RefreshType: RefreshTypeIncremental},
+			Permissions: []PermissionRule{{Group: "Analysts", Capabilities: map[Name]Mode{"Read": ModeAllow, "ExportData": ModeDeny}}},
+			Connections: map[string]ConnectionOverride{"Sales DWH": {ServerAddress: "dwh-test.example.com"}},
+		}, ""},
+		{"missing version", DocumentConfig{}, "version is missing"},
+		{"unsupported version", DocumentConfig{Version: 2}, "version 2 is not supported"},
+		{"invalid project", DocumentConfig{Version: 1, Project: "/"}, "invalid project path '/'"},
+		{"empty tag", DocumentConfig{Version: 1, Tags: []string{" "}}, "invalid tag ' '"},
+		{"tag with comma", DocumentConfig{Version: 1, Tags: []string{"a,b"}}, "invalid tag 'a,b'"},
+		{"schedule without name", DocumentConfig{Version: 1, Schedule: &ScheduleConfig{}}, "schedule without name"},
+		{"invalid refresh type", DocumentConfig{Version: 1, Schedule: &ScheduleConfig{Name: "Nightly", RefreshType: "incremental"}}, "invalid refreshType 'incremental'"},
+		{"permission without grantee", DocumentConfig{Version: 1, Permissions: []PermissionRule{{Capabilities: map[Name]Mode{"Read": ModeAllow}}}}, "permission rule 1: expecting either a group or a user"},
+		{"permission with group and user", DocumentConfig{Version: 1, Permissions: []PermissionRule{{Group: "Analysts", User: "jdoe", Capabilities: map[Name]Mode{"Read": ModeAllow}}}}, "permission rule 1: expecting either a group or a user"},
+		{"permission without capabilities", DocumentConfig{Version: 1, Permissions: []PermissionRule{{Group: "Analysts"}}}, "permission rule 1: no capabilities"},
+		{"invalid mode", DocumentConfig{Version: 1, Permissions: []PermissionRule{{Group: "Analysts", Capabilities: map[Name]Mode{"Read": "allow"}}}}, "invalid mode 'allow' for capability Read"},
+		{"connection without caption", DocumentConfig{Version: 1, Connections: map[string]ConnectionOverride{"": {UserName: "reader"}}}, "connection override without caption"},
+		{"empty connection override", DocumentConfig{Version: 1, Connections: map[string]ConnectionOverride{"Sales DWH": {}}}, "connection override 'Sales DWH' does not override anything"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.config.Validate()
+			switch {
+			case test.problem == "" && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case test.problem != "" && err == nil:
+				t.Errorf("expecting error '%s'", test.problem)
+			case test.problem != "" && !strings.Contains(err.Error(), test.problem):
+				t.Errorf("error '%v' does not contain '%s'", err, test.problem)
+			}
+		})
+	}
+}
+
+func TestDocumentConfigValidateReportsAllProblems(t *testing.T) {
+	err := DocumentConfig{Tags: []string{""}, Schedule: &ScheduleConfig{}}.Validate()
+	if err == nil {
+		t.Fatalf("expecting an error")
+	}
+	if problems := strings.Split(err.Error(), "; "); len(problems) != 3 {
+		t.Errorf("expecting 3 problems, got %q", problems)
+	}
+}
+
+// writeDocument writes an (empty) document and its config files to a temporary directory
+// and returns the path of the document
+func writeDocument(t *testing.T, name string, configs map[string]string) (string, func()) {
+	dir, err := ioutil.TempDir("", "tabgo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	documentPath := filepath.Join(dir, name)
+	if err = ioutil.WriteFile(documentPath, []byte{}, 0644); err != nil {
+		t.Fatal(err)
+	}
+	for suffix, content := range configs {
+		if err = ioutil.WriteFile(documentPath+suffix, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return documentPath, func() { os.RemoveAll(dir) }
+}
+
+func TestLoadDocumentConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		configs map[string]string
+		found   bool
+		config  DocumentConfig
+		// problem is part of the expected error, "" when the config is valid
+		problem string
+	}{
+		{
+			name: "no config",
+		},
+		{
+			name:    "yaml",
+			configs: map[string]string{".tabgo.yaml": "version: 1\nproject: Finance/Sales\ntags: [finance]\n"},
+			found:   true,
+			config:  DocumentConfig{Version: 1, Project: "Finance/Sales", Tags: []string{"finance"}},
+		},
+		{
+			name:    "json",
+			configs: map[string]string{".tabgo.json": `{"version": 1, "extract": {"encrypt": true}}`},
+			found:   true,
+			config:  DocumentConfig{Version: 1, Extract: &ExtractConfig{Encrypt: true}},
+		},
+		{
+			name:    "unknown key in versioned config",
+			configs: map[string]string{".tabgo.yaml": "version: 1\nprojects: Finance\n"},
+			found:   true,
+			problem: "field projects not found",
+		},
+		{
+			name:    "invalid versioned config",
+			configs: map[string]string{".tabgo.yml": "project: Finance\n"},
+			found:   true,
+			problem: "version is missing",
+		},
+		{
+			name:    "more than one config",
+			configs: map[string]string{".tabgo.yaml": "version: 1\n", ".json": "{}"},
+			problem: "more than one config file",
+		},
+		{
+			name:    "legacy",
+			configs: map[string]string{".json": `{"ExtractDataSourceData":true,"EncryptData":true,"RefreshType":"Incremental","Schedule":"Nightly"}`},
+			found:   true,
+			config: DocumentConfig{
+				Version:  1,
+				Extract:  &ExtractConfig{Encrypt: true},
+				Schedule: &ScheduleConfig{Name: "Nightly", RefreshType: RefreshTypeIncremental},
+			},
+		},
+		{
+			name:    "legacy keys and refresh type are case insensitive",
+			configs: map[string]string{".json": `{"extractDataSourceData":true,"encryptdata":false,"refreshType":"incremental","schedule":"Nightly"}`},
+			found:   true,
+			config: DocumentConfig{
+				Version:  1,
+				Extract:  &ExtractConfig{},
+				Schedule: &ScheduleConfig{Name: "Nightly", RefreshType: RefreshTypeIncremental},
+			},
+		},
+		{
+			name:    "legacy unknown keys are ignored",
+			configs: map[string]string{".json": `{"ExtractDataSourceData":false,"Comment":"kept from before"}`},
+			found:   true,
+			config:  DocumentConfig{Version: 1},
+		},
+		{
+			name:    "legacy without schedule ignores refresh type",
+			configs: map[string]string{".json": `{"RefreshType":"Incremental"}`},
+			found:   true,
+			config:  DocumentConfig{Version: 1},
+		},
+		{
+			name:    "invalid legacy json",
+			configs: map[string]string{".json": `ExtractDataSourceData: true`},
+			found:   true,
+			problem: "can not json decode",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			documentPath, cleanup := writeDocument(t, "Sales.tds", test.configs)
+			defer cleanup()
+
+			config, found, err := LoadDocumentConfig(documentPath)
+			if found != test.found {
+				t.Errorf("found = %t, expecting %t", found, test.found)
+			}
+			switch {
+			case test.problem == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case test.problem != "" && err == nil:
+				t.Fatalf("expecting error '%s'", test.problem)
+			case test.problem != "" && !strings.Contains(err.Error(), test.problem):
+				t.Fatalf("error '%v' does not contain '%s'", err, test.problem)
+			}
+			if test.problem == "" && !reflect.DeepEqual(config, test.config) {
+				t.Errorf("config = %+v, expecting %+v", config, test.config)
+			}
+		})
+	}
+}
+
+func TestDocumentConfigValidateCertification(t *testing.T) {
+	tests := []struct {
+		name          string
+		documentPath  string
+		certification *CertificationConfig
+		// problem is part of the expected error, "" when the config is valid
+		problem string
+	}{
+		{"certified datasource", "Sales.tds", &CertificationConfig{Certified: true, Note: "Reviewed"}, ""},
+		{"uncertified datasource", "Sales.tdsx", &CertificationConfig{}, ""},
+		{"certified without note", "Sales.tds", &CertificationConfig{Certified: true, Note: " "}, "certification without note"},
+		{"workbook", "Sales.twb", &CertificationConfig{Certified: true, Note: "Reviewed"}, "only datasources can be certified"},
+		{"packaged workbook", "Sales.twbx", &CertificationConfig{}, "only datasources can be certified"},
+		{"workbook without certification", "Sales.twbx", nil, ""},
+		{"unknown document", "", &CertificationConfig{Certified: true, Note: "Reviewed"}, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := DocumentConfig{Version: 1, Certification: test.certification}.ValidateDocument(test.documentPath)
+			switch {
+			case test.problem == "" && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case test.problem != "" && err == nil:
+				t.Errorf("expecting error '%s'", test.problem)
+			case test.problem != "" && !strings.Contains(err.Error(), test.problem):
+				t.Errorf("error '%v' does not contain '%s'", err, test.problem)
+			}
+		})
+	}
+}
+
+func TestReadDocumentConfigRejectsWorkbookCertification(t *testing.T) {
+	documentPath, cleanup := writeDocument(t, "Sales.twb", map[string]string{
+		".tabgo.yaml": "version: 1\ncertification:\n  certified: true\n",
+	})
+	defer cleanup()
+
+	_, err := ReadDocumentConfig(documentPath + ".tabgo.yaml")
+	if err == nil {
+		t.Fatalf("expecting an error")
+	}
+	// both problems are reported by a single validation
+	for _, problem := range []string{"certification without note", "only datasources can be certified"} {
+		if !strings.Contains(err.Error(), problem) {
+			t.Errorf("error '%v' does not contain '%s'", err, problem)
+		}
+	}
+}
+
+func TestDocumentOfConfig(t *testing.T) {
+	tests := []struct {
+		path     string
+		config   bool
+		document string
+	}{
+		{"Sales.tds.tabgo.yaml", true, "Sales.tds"},
+		{"Sales.twbx.tabgo.json", true, "Sales.twbx"},
+		{"Sales.tabgo.yml", true, "Sales"},
+		{"Sales.tds.json", true, "Sales.tds"},
+		{"connections.json", false, "connections.json"},
+		{"Sales.tds", false, "Sales.tds"},
+	}
+	for _, test := range tests {
+		if config := IsDocumentConfig(test.path); config != test.config {
+			t.Errorf("IsDocumentConfig(%q) = %t, expecting %t", test.path, config, test.config)
+		}
+		if document := DocumentOfConfig(test.path); document != test.document {
+			t.Errorf("DocumentOfConfig(%q) = %q, expecting %q", test.path, document, test.document)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package tableau
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// DownloadWorkbook downloads a workbook (twb or twbx) into dir and returns the path of the downloaded file.
+// The file is named after the workbook, so publishing it again keeps the name.
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_workbooksviews.htm#download_workbook
+func (tabl *TabGo) DownloadWorkbook(workbookID, name, dir string, includeExtract bool) (string, error) {
+	uri := fmt.Sprintf("%s/workbooks/%s/content?includeExtract=%t", tabl.SiteURL(), workbookID, includeExtract)
+	path, err := tabl.downloadContent(uri, name, dir)
+	if err != nil {
+		return path, errors.Wrapf(err, "can not download workbook '%s'", name)
+	}
+	return path, nil
+}
+
+// DownloadDatasource downloads a published datasource (tds or tdsx) into dir and returns the path of the downloaded file.
+// The file is named after the datasource, so publishing it again keeps the name.
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_datasources.htm#download_data_source
+func (tabl *TabGo) DownloadDatasource(datasourceID, name, dir string, includeExtract bool) (string, error) {
+	uri := fmt.Sprintf("%s/datasources/%s/content?includeExtract=%t", tabl.SiteURL(), datasourceID, includeExtract)
+	path, err := tabl.downloadContent(uri, name, dir)
+	if err != nil {
+		return path, errors.Wrapf(err, "can not download datasource '%s'", name)
+	}
+	return path, nil
+}
+
+// downloadContent writes the response body of uri to dir/name,
+// the file extension is taken from the filename tableau sends in the Content-Disposition header
+func (tabl *TabGo) downloadContent(uri, name, dir string) (string, error) {
+	req, err := http.NewRequest("GET", uri, nil)
+	if err != nil {
+		return "", errors.Wrapf(err, "can not create GET request for '%s'", uri)
+	}
+	req.Header.Set("X-tableau-auth", tabl.CurrentToken)
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", errors.Wrapf(err, "can not client.Do(request) GET '%s'", uri)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return "", fmt.Errorf("GET '%s' failed with status %d: %s", uri, resp.StatusCode, string(body))
+	}
+
+	_, params, err := mime.ParseMediaType(resp.Header.Get("Content-Disposition"))
+	if err != nil || params["filename"] == "" {
+		return "", fmt.Errorf("no filename in Content-Disposition '%s' of '%s'", resp.Header.Get("Content-Disposition"), uri)
+	}
+
+	fileName := safeFileName(name) + filepath.Ext(params["filename"])
+	path := filepath.Join(dir, fileName)
+	file, err := os.Create(path)
+	if err != nil {
+		return "", errors.Wrapf(err, "can not create %s", path)
+	}
+	defer file.Close()
+
+	if _, err = io.Copy(file, resp.Body); err != nil {
+		return path, errors.Wrapf(err, "can not write %s", path)
+	}
+	return path, nil
+}
+
+// safeFileName returns name without the characters that can not be part of a file name
+func safeFileName(name string) string {
+	return strings.NewReplacer("/", "_", `\`, "_").Replace(name)
+}
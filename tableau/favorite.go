@@ -0,0 +1,157 @@
+package tableau
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// ListFavorites returns the favorites of a user
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_favorites.htm#get_favorites_for_user
+func (tabl *TabGo) ListFavorites(userID string) ([]FavoriteType, error) {
+	favorites := []FavoriteType{}
+	err := tabl.forEachPage(fmt.Sprintf("%s/favorites/%s", tabl.SiteURL(), userID), func(tsResponse TsResponse) int {
+		favorites = append(favorites, tsResponse.Favorites.Favorite...)
+		return len(tsResponse.Favorites.Favorite)
+	})
+	if err != nil {
+		return favorites, errors.Wrapf(err, "can not list favorites of user '%s'", userID)
+	}
+	return favorites, nil
+}
+
+// AddFavorite adds a workbook, datasource, view, flow or project to the favorites of a user
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_favorites.htm#add_favorites
+func (tabl *TabGo) AddFavorite(userID, label string, kind ContentKind, contentID string) ([]FavoriteType, error) {
+	tsResponse, err := tabl.doTsRequest("PUT", fmt.Sprintf("%s/favorites/%s", tabl.SiteURL(), userID),
+		fmt.Sprintf(`<tsRequest><favorite label="%s"><%s id="%s" /></favorite></tsRequest>`, xmlEscape(label), kind.element(), contentID))
+	if err != nil {
+		return tsResponse.Favorites.Favorite, errors.Wrapf(err, "can not add %s '%s' to the favorites of user '%s'", kind.element(), contentID, userID)
+	}
+	return tsResponse.Favorites.Favorite, nil
+}
+
+// DeleteFavorite removes a workbook, datasource, view, flow or project from the favorites of a user
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_favorites.htm#delete_workbook_from_favorites
+func (tabl *TabGo) DeleteFavorite(userID string, kind ContentKind, contentID string) error {
+	_, err := tabl.doRequest("DELETE", fmt.Sprintf("%s/favorites/%s/%s/%s", tabl.SiteURL(), userID, kind, contentID), "")
+	if err != nil {
+		return errors.Wrapf(err, "can not delete %s '%s' from the favorites of user '%s'", kind.element(), contentID, userID)
+	}
+	return nil
+}
+
+// FavoriteContent returns the kind and id of the content a favorite points at,
+// an empty kind for a favorite of a kind of content tabgo does not know
+func FavoriteContent(favorite FavoriteType) (ContentKind, ResourceIdType) {
+	switch {
+	case favorite.View.Id != "":
+		return KindView, favorite.View.Id
+	case favorite.Workbook.Id != "":
+		return KindWorkbook, favorite.Workbook.Id
+	case favorite.Datasource.Id != "":
+		return KindDatasource, favorite.Datasource.Id
+	case favorite.Flow.Id != "":
+		return KindFlow, favorite.Flow.Id
+	case favorite.Project.Id != "":
+		return KindProject, favorite.Project.Id
+	}
+	return "", ""
+}
+
+// FavoriteChangeAction is what a FavoriteChange does on the server
+type FavoriteChangeAction string
+
+const (
+	FavoriteAdd    FavoriteChangeAction = "add"
+	FavoriteRemove FavoriteChangeAction = "remove"
+)
+
+// FavoriteChange adds content to or removes content from the favorites of a user
+type FavoriteChange struct {
+	Action    FavoriteChangeAction
+	User      UserType
+	Kind      ContentKind
+	ContentID ResourceIdType
+	Label     string
+}
+
+func (change FavoriteChange) String() string {
+	symbol := map[FavoriteChangeAction]string{FavoriteAdd: "+", FavoriteRemove: "-"}[change.Action]
+	return fmt.Sprintf("%s %s: %s %s %s (%s)", symbol, change.User.Name, change.Action, change.Kind.element(), change.Label, change.ContentID)
+}
+
+// PlanFavorites returns the changes adding content to the favorites of users, e.g. the key dashboards of new users.
+// Users that already have the content as favorite are left alone.
+func (tabl *TabGo) PlanFavorites(users []UserType, kind ContentKind, contentID ResourceIdType, label string) ([]FavoriteChange, error) {
+	changes := []FavoriteChange{}
+	for _, user := range users {
+		favorites, err := tabl.ListFavorites(string(user.Id))
+		if err != nil {
+			return changes, err
+		}
+		found := false
+		for _, favorite := range favorites {
+			if favoriteKind, id := FavoriteContent(favorite); favoriteKind == kind && id == contentID {
+				found = true
+			}
+		}
+		if !found {
+			changes = append(changes, FavoriteChange{Action: FavoriteAdd, User: user, Kind: kind, ContentID: contentID, Label: label})
+		}
+	}
+	return changes, nil
+}
+
+// PlanFavoritePrune returns the changes removing the favorites of users that point at content that no longer exists,
+// favorites of an unknown kind of content are left alone
+func (tabl *TabGo) PlanFavoritePrune(users []UserType) ([]FavoriteChange, error) {
+	changes := []FavoriteChange{}
+	existing := make(map[ResourceIdType]bool)
+	for _, kind := range ContentKinds {
+		contents, err := tabl.ListContent(kind, "")
+		if err != nil {
+			return changes, err
+		}
+		for _, content := range contents {
+			existing[content.Id] = true
+		}
+	}
+	projects, err := tabl.ListProjects()
+	if err != nil {
+		return changes, err
+	}
+	for _, project := range projects {
+		existing[project.Id] = true
+	}
+
+	for _, user := range users {
+		favorites, err := tabl.ListFavorites(string(user.Id))
+		if err != nil {
+			return changes, err
+		}
+		for _, favorite := range favorites {
+			kind, id := FavoriteContent(favorite)
+			if kind != "" && !existing[id] {
+				changes = append(changes, FavoriteChange{Action: FavoriteRemove, User: user, Kind: kind, ContentID: id, Label: favorite.Label})
+			}
+		}
+	}
+	return changes, nil
+}
+
+// ApplyFavoriteChanges executes favorite changes in order
+func (tabl *TabGo) ApplyFavoriteChanges(changes []FavoriteChange) error {
+	for _, change := range changes {
+		var err error
+		if change.Action == FavoriteAdd {
+			_, err = tabl.AddFavorite(string(change.User.Id), change.Label, change.Kind, string(change.ContentID))
+		} else {
+			err = tabl.DeleteFavorite(string(change.User.Id), change.Kind, string(change.ContentID))
+		}
+		if err != nil {
+			return errors.Wrapf(err, "can not apply '%s'", change)
+		}
+	}
+	return nil
+}
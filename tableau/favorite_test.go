@@ -0,0 +1,55 @@
+package tableau
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFavoriteContent(t *testing.T) {
+	tests := []struct {
+		name     string
+		favorite FavoriteType
+		kind     ContentKind
+		id       ResourceIdType
+	}{
+		{"view", FavoriteType{View: ViewType{Id: "v1"}, Workbook: WorkbookType{Id: "w1"}}, KindView, "v1"},
+		{"workbook", FavoriteType{Workbook: WorkbookType{Id: "w1"}}, KindWorkbook, "w1"},
+		{"datasource", FavoriteType{Datasource: DataSourceType{Id: "d1"}}, KindDatasource, "d1"},
+		{"flow", FavoriteType{Flow: FlowType{Id: "f1"}}, KindFlow, "f1"},
+		{"project", FavoriteType{Project: ProjectType{Id: "p1"}}, KindProject, "p1"},
+		{"unknown", FavoriteType{Label: "metric"}, "", ""},
+	}
+	for _, test := range tests {
+		if kind, id := FavoriteContent(test.favorite); kind != test.kind || id != test.id {
+			t.Errorf("%s: FavoriteContent = %s %s, expecting %s %s", test.name, kind, id, test.kind, test.id)
+		}
+	}
+}
+
+func TestPlanFavoritePrune(t *testing.T) {
+	tabl, cleanup := newTestServer(t, map[string]string{
+		"/projects":  `<projects><project id="finance" name="Finance" /></projects>`,
+		"/workbooks": `<workbooks><workbook id="revenue" name="Revenue"><project id="finance" /></workbook></workbooks>`,
+		"/favorites/u1": `<favorites>
+			<favorite label="Revenue"><workbook id="revenue" /></favorite>
+			<favorite label="Costs"><workbook id="costs" /></favorite>
+			<favorite label="Finance"><project id="finance" /></favorite>
+			<favorite label="Old"><project id="old" /></favorite>
+			<favorite label="Metric"><metric id="m1" /></favorite>
+		</favorites>`,
+	})
+	defer cleanup()
+
+	changes, err := tabl.PlanFavoritePrune([]UserType{{Id: "u1", Name: "jdoe"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := []string{}
+	for _, change := range changes {
+		lines = append(lines, change.String())
+	}
+	expected := []string{"- jdoe: remove workbook Costs (costs)", "- jdoe: remove project Old (old)"}
+	if !reflect.DeepEqual(lines, expected) {
+		t.Errorf("plan:\n%q\nexpecting:\n%q", lines, expected)
+	}
+}
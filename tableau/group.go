@@ -0,0 +1,139 @@
+package tableau
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// ListGroups returns all groups of the current site matching the (optional) filter expression
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_users_and_groups.htm#query_groups
+func (tabl *TabGo) ListGroups(filter string) ([]GroupType, error) {
+	groups := []GroupType{}
+	err := tabl.forEachPage(tabl.listURI("groups", filter), func(tsResponse TsResponse) int {
+		groups = append(groups, tsResponse.Groups.Group...)
+		return len(tsResponse.Groups.Group)
+	})
+	if err != nil {
+		return groups, errors.Wrapf(err, "can not list groups")
+	}
+	return groups, nil
+}
+
+// GetGroupByName returns the group of the current site with the given name
+func (tabl *TabGo) GetGroupByName(name string) (GroupType, error) {
+	groups, err := tabl.ListGroups("name:eq:" + name)
+	if err != nil {
+		return GroupType{}, err
+	}
+	for _, group := range groups {
+		if group.Name == name {
+			return group, nil
+		}
+	}
+	return GroupType{}, fmt.Errorf("no group '%s' found on site '%s'", name, tabl.CurrentSiteName)
+}
+
+// CreateGroup creates a local group on the current site,
+// minimumSiteRole (optional) is the site role members get when they are added to the site on sign in
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_users_and_groups.htm#create_group
+func (tabl *TabGo) CreateGroup(name string, minimumSiteRole SiteRoleType) (GroupType, error) {
+	attributes := fmt.Sprintf(`name="%s"`, xmlEscape(name))
+	if minimumSiteRole != "" {
+		attributes += fmt.Sprintf(` minimumSiteRole="%s"`, minimumSiteRole)
+	}
+	tsResponse, err := tabl.doTsRequest("POST", fmt.Sprintf("%s/groups", tabl.SiteURL()),
+		fmt.Sprintf(`<tsRequest><group %s /></tsRequest>`, attributes))
+	if err != nil {
+		return tsResponse.Group, errors.Wrapf(err, "can not create group '%s'", name)
+	}
+	return tsResponse.Group, nil
+}
+
+// ImportADGroup creates a group on the current site from an Active Directory group,
+// its members are added to the site with the given site role.
+// Only servers using Active Directory authentication support this.
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_users_and_groups.htm#create_group
+func (tabl *TabGo) ImportADGroup(name string, directive ImportDirectiveType) (GroupType, error) {
+	tsResponse, err := tabl.doTsRequest("POST", fmt.Sprintf("%s/groups?asJob=false", tabl.SiteURL()),
+		fmt.Sprintf(`<tsRequest><group name="%s">%s</group></tsRequest>`, xmlEscape(name), importDirectiveElement(directive)))
+	if err != nil {
+		return tsResponse.Group, errors.Wrapf(err, "can not import Active Directory group '%s'", name)
+	}
+	return tsResponse.Group, nil
+}
+
+// SynchronizeADGroup updates the members of a group imported from Active Directory
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_users_and_groups.htm#update_group
+func (tabl *TabGo) SynchronizeADGroup(groupID, name string, directive ImportDirectiveType) (GroupType, error) {
+	tsResponse, err := tabl.doTsRequest("PUT", fmt.Sprintf("%s/groups/%s?asJob=false", tabl.SiteURL(), groupID),
+		fmt.Sprintf(`<tsRequest><group name="%s">%s</group></tsRequest>`, xmlEscape(name), importDirectiveElement(directive)))
+	if err != nil {
+		return tsResponse.Group, errors.Wrapf(err, "can not synchronize Active Directory group '%s'", name)
+	}
+	return tsResponse.Group, nil
+}
+
+func importDirectiveElement(directive ImportDirectiveType) string {
+	source := directive.Source
+	if source == "" {
+		source = "ActiveDirectory"
+	}
+	return fmt.Sprintf(`<import source="%s" domainName="%s" siteRole="%s" />`, source, xmlEscape(directive.DomainName), directive.SiteRole)
+}
+
+// RenameGroup changes the name of a local group
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_users_and_groups.htm#update_group
+func (tabl *TabGo) RenameGroup(groupID, name string) (GroupType, error) {
+	tsResponse, err := tabl.doTsRequest("PUT", fmt.Sprintf("%s/groups/%s", tabl.SiteURL(), groupID),
+		fmt.Sprintf(`<tsRequest><group name="%s" /></tsRequest>`, xmlEscape(name)))
+	if err != nil {
+		return tsResponse.Group, errors.Wrapf(err, "can not rename group '%s'", groupID)
+	}
+	return tsResponse.Group, nil
+}
+
+// DeleteGroup deletes a group from the current site, its members stay on the site
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_users_and_groups.htm#delete_group
+func (tabl *TabGo) DeleteGroup(groupID string) error {
+	_, err := tabl.doRequest("DELETE", fmt.Sprintf("%s/groups/%s", tabl.SiteURL(), groupID), "")
+	if err != nil {
+		return errors.Wrapf(err, "can not delete group '%s'", groupID)
+	}
+	return nil
+}
+
+// ListGroupUsers returns the members of a group
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_users_and_groups.htm#get_users_in_group
+func (tabl *TabGo) ListGroupUsers(groupID string) ([]UserType, error) {
+	users := []UserType{}
+	err := tabl.forEachPage(fmt.Sprintf("%s/groups/%s/users", tabl.SiteURL(), groupID), func(tsResponse TsResponse) int {
+		users = append(users, tsResponse.Users.User...)
+		return len(tsResponse.Users.User)
+	})
+	if err != nil {
+		return users, errors.Wrapf(err, "can not list users of group '%s'", groupID)
+	}
+	return users, nil
+}
+
+// AddUserToGroup makes a user of the current site a member of a group
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_users_and_groups.htm#add_user_to_group
+func (tabl *TabGo) AddUserToGroup(groupID, userID string) (UserType, error) {
+	tsResponse, err := tabl.doTsRequest("POST", fmt.Sprintf("%s/groups/%s/users", tabl.SiteURL(), groupID),
+		fmt.Sprintf(`<tsRequest><user id="%s" /></tsRequest>`, userID))
+	if err != nil {
+		return tsResponse.User, errors.Wrapf(err, "can not add user '%s' to group '%s'", userID, groupID)
+	}
+	return tsResponse.User, nil
+}
+
+// RemoveUserFromGroup removes a member from a group
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_users_and_groups.htm#remove_user_to_group
+func (tabl *TabGo) RemoveUserFromGroup(groupID, userID string) error {
+	_, err := tabl.doRequest("DELETE", fmt.Sprintf("%s/groups/%s/users/%s", tabl.SiteURL(), groupID, userID), "")
+	if err != nil {
+		return errors.Wrapf(err, "can not remove user '%s' from group '%s'", userID, groupID)
+	}
+	return nil
+}
@@ -0,0 +1,287 @@
+package tableau
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// group sync counters, named after the counters tableau reports for group and user imports
+const (
+	CountOfUsersAddedToGroup     Type = "CountOfUsersAddedToGroup"
+	CountOfUsersRemovedFromGroup Type = "CountOfUsersRemovedFromGroup"
+	CountOfUsersProcessed        Type = "CountOfUsersProcessed"
+	CountOfUsersSkipped          Type = "CountOfUsersSkipped"
+)
+
+// GroupSpec is the desired state of a group.
+// A group with an Import directive is an Active Directory group: its members are synchronized from Active Directory
+// and Members is ignored.
+type GroupSpec struct {
+	Name            string               `yaml:"name"`
+	MinimumSiteRole SiteRoleType         `yaml:"minimumSiteRole,omitempty"`
+	Members         []string             `yaml:"members,omitempty"`
+	Import          *ImportDirectiveSpec `yaml:"import,omitempty"`
+}
+
+// ImportDirectiveSpec describes the Active Directory group a group is imported from
+type ImportDirectiveSpec struct {
+	DomainName string       `yaml:"domainName"`
+	SiteRole   SiteRoleType `yaml:"siteRole"`
+}
+
+// GroupMembership is the desired membership of the groups of a site
+// Example yaml:
+//
+//	groups:
+//	  - name: Analysts
+//	    members: [jdoe, asmith]
+//	  - name: Finance
+//	    import:
+//	      domainName: example.com
+//	      siteRole: Viewer
+type GroupMembership struct {
+	Groups []GroupSpec `yaml:"groups"`
+}
+
+// ReadGroupMembership reads the desired group membership from a yaml file
+func ReadGroupMembership(path string) (GroupMembership, error) {
+	membership := GroupMembership{}
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return membership, errors.Wrapf(err, "can not read %s", path)
+	}
+	err = yaml.UnmarshalStrict(content, &membership)
+	if err != nil {
+		return membership, errors.Wrapf(err, "can not yaml decode %s", path)
+	}
+	return membership, nil
+}
+
+// GroupChangeAction is what a GroupChange does on the server
+type GroupChangeAction string
+
+const (
+	GroupCreate       GroupChangeAction = "create"
+	GroupImport       GroupChangeAction = "import"
+	GroupSynchronize  GroupChangeAction = "synchronize"
+	GroupAddMember    GroupChangeAction = "add"
+	GroupRemoveMember GroupChangeAction = "remove"
+	GroupSkipMember   GroupChangeAction = "skip"
+	GroupSkip         GroupChangeAction = "skip group"
+)
+
+// GroupChange is a single step of a group sync plan
+type GroupChange struct {
+	Action GroupChangeAction
+	Group  GroupSpec
+	// GroupID is unset when the group is created by an earlier change
+	GroupID string
+	User    UserType
+	Reason  string
+}
+
+func (change GroupChange) String() string {
+	switch change.Action {
+	case GroupAddMember, GroupRemoveMember:
+		symbol := map[GroupChangeAction]string{GroupAddMember: "+", GroupRemoveMember: "-"}[change.Action]
+		return fmt.Sprintf("%s %s: %s %s", symbol, change.Group.Name, change.Action, change.User.Name)
+	case GroupSkipMember:
+		return fmt.Sprintf("! %s: skip %s (%s)", change.Group.Name, change.User.Name, change.Reason)
+	case GroupSkip:
+		return fmt.Sprintf("! %s: skip (%s)", change.Group.Name, change.Reason)
+	case GroupImport, GroupSynchronize:
+		line := fmt.Sprintf("~ %s: %s from Active Directory domain %s", change.Group.Name, change.Action, change.Group.Import.DomainName)
+		if change.Reason != "" {
+			line += " (" + change.Reason + ")"
+		}
+		return line
+	}
+	return fmt.Sprintf("+ %s: %s", change.Group.Name, change.Action)
+}
+
+// GroupSyncSummary counts what a group sync did, by tableau counter type,
+// CountOfUsersProcessed are the members tableau processed while synchronizing Active Directory groups
+type GroupSyncSummary map[Type]int
+
+func (summary GroupSyncSummary) String() string {
+	return fmt.Sprintf("users synchronized from Active Directory: %d, added: %d, removed: %d, skipped: %d",
+		summary[CountOfUsersProcessed], summary[CountOfUsersAddedToGroup], summary[CountOfUsersRemovedFromGroup], summary[CountOfUsersSkipped])
+}
+
+// PlanGroupSync compares the desired group membership with the groups on the current site
+// and returns the changes needed to make the site match it.
+// Groups on the site that are not in the membership are left alone.
+// An existing Active Directory group is only synchronized when its import directive differs from the spec,
+// or with resync, e.g. to pick up membership changes in Active Directory.
+// A spec that does not match the kind of its existing group, local or Active Directory, is skipped.
+func (tabl *TabGo) PlanGroupSync(membership GroupMembership, resync bool) ([]GroupChange, error) {
+	groups, err := tabl.ListGroups("")
+	if err != nil {
+		return []GroupChange{}, err
+	}
+	users, err := tabl.ListUsers("")
+	if err != nil {
+		return []GroupChange{}, err
+	}
+	members := make(map[ResourceIdType][]UserType)
+	for _, spec := range membership.Groups {
+		if existing, found := findGroup(groups, spec.Name); found && spec.Import == nil && existing.Import.DomainName == "" {
+			groupMembers, err := tabl.ListGroupUsers(string(existing.Id))
+			if err != nil {
+				return []GroupChange{}, err
+			}
+			members[existing.Id] = groupMembers
+		}
+	}
+	return planGroupSync(membership, groups, members, users, resync), nil
+}
+
+// findGroup returns the group of groups with the given name
+func findGroup(groups []GroupType, name string) (GroupType, bool) {
+	for _, group := range groups {
+		if group.Name == name {
+			return group, true
+		}
+	}
+	return GroupType{}, false
+}
+
+// planGroupSync returns the changes that make groups match membership,
+// members are the current members of the existing local groups of membership, by group id
+func planGroupSync(membership GroupMembership, groups []GroupType, members map[ResourceIdType][]UserType, users []UserType, resync bool) []GroupChange {
+	changes := []GroupChange{}
+	usersByName := make(map[string]UserType)
+	for _, user := range users {
+		usersByName[strings.ToLower(user.Name)] = user
+	}
+
+	for _, spec := range membership.Groups {
+		existing, found := findGroup(groups, spec.Name)
+
+		if spec.Import != nil {
+			switch {
+			case !found:
+				changes = append(changes, GroupChange{Action: GroupImport, Group: spec})
+			case existing.Import.DomainName == "":
+				changes = append(changes, GroupChange{Action: GroupSkip, Group: spec, GroupID: string(existing.Id),
+					Reason: "a local group can not be synchronized from Active Directory"})
+			default:
+				if reason := importDrift(existing.Import, *spec.Import); reason != "" || resync {
+					changes = append(changes, GroupChange{Action: GroupSynchronize, Group: spec, GroupID: string(existing.Id), Reason: reason})
+				}
+			}
+			continue
+		}
+		if found && existing.Import.DomainName != "" {
+			changes = append(changes, GroupChange{Action: GroupSkip, Group: spec, GroupID: string(existing.Id),
+				Reason: fmt.Sprintf("members of a group imported from Active Directory domain %s can not be changed", existing.Import.DomainName)})
+			continue
+		}
+
+		current := make(map[string]UserType)
+		groupID := ""
+		if !found {
+			changes = append(changes, GroupChange{Action: GroupCreate, Group: spec})
+		} else {
+			groupID = string(existing.Id)
+			for _, member := range members[existing.Id] {
+				current[strings.ToLower(member.Name)] = member
+			}
+		}
+
+		desired := make(map[string]bool)
+		for _, name := range spec.Members {
+			key := strings.ToLower(name)
+			desired[key] = true
+			if _, found := current[key]; found {
+				continue
+			}
+			user, found := usersByName[key]
+			if !found {
+				changes = append(changes, GroupChange{Action: GroupSkipMember, Group: spec, GroupID: groupID, User: UserType{Name: name}, Reason: "no such user on the site"})
+				continue
+			}
+			changes = append(changes, GroupChange{Action: GroupAddMember, Group: spec, GroupID: groupID, User: user})
+		}
+
+		removals := []GroupChange{}
+		for key, member := range current {
+			if !desired[key] {
+				removals = append(removals, GroupChange{Action: GroupRemoveMember, Group: spec, GroupID: groupID, User: member})
+			}
+		}
+		sort.Slice(removals, func(i, j int) bool { return removals[i].User.Name < removals[j].User.Name })
+		changes = append(changes, removals...)
+	}
+	return changes
+}
+
+// importDrift describes how the import directive of a group on the site differs from spec, "" when it does not
+func importDrift(current ImportDirectiveType, spec ImportDirectiveSpec) string {
+	details := []string{}
+	if !strings.EqualFold(current.DomainName, spec.DomainName) {
+		details = append(details, fmt.Sprintf("domainName: '%s' -> '%s'", current.DomainName, spec.DomainName))
+	}
+	if current.SiteRole != spec.SiteRole {
+		details = append(details, fmt.Sprintf("siteRole: '%s' -> '%s'", current.SiteRole, spec.SiteRole))
+	}
+	return strings.Join(details, ", ")
+}
+
+// ApplyGroupSync executes the changes of a group sync plan, in order, and counts what it did
+func (tabl *TabGo) ApplyGroupSync(changes []GroupChange) (GroupSyncSummary, error) {
+	summary := GroupSyncSummary{}
+	groupIDs := make(map[string]string)
+
+	for _, change := range changes {
+		groupID := change.GroupID
+		if groupID == "" {
+			groupID = groupIDs[change.Group.Name]
+		}
+
+		switch change.Action {
+		case GroupCreate:
+			group, err := tabl.CreateGroup(change.Group.Name, change.Group.MinimumSiteRole)
+			if err != nil {
+				return summary, err
+			}
+			groupIDs[change.Group.Name] = string(group.Id)
+
+		case GroupImport, GroupSynchronize:
+			directive := ImportDirectiveType{DomainName: change.Group.Import.DomainName, SiteRole: change.Group.Import.SiteRole}
+			var group GroupType
+			var err error
+			if change.Action == GroupImport {
+				group, err = tabl.ImportADGroup(change.Group.Name, directive)
+			} else {
+				group, err = tabl.SynchronizeADGroup(groupID, change.Group.Name, directive)
+			}
+			if err != nil {
+				return summary, err
+			}
+			groupIDs[change.Group.Name] = string(group.Id)
+			summary[CountOfUsersProcessed] += group.UserCount
+
+		case GroupAddMember:
+			if _, err := tabl.AddUserToGroup(groupID, string(change.User.Id)); err != nil {
+				return summary, err
+			}
+			summary[CountOfUsersAddedToGroup]++
+
+		case GroupRemoveMember:
+			if err := tabl.RemoveUserFromGroup(groupID, string(change.User.Id)); err != nil {
+				return summary, err
+			}
+			summary[CountOfUsersRemovedFromGroup]++
+
+		case GroupSkipMember:
+			summary[CountOfUsersSkipped]++
+		}
+	}
+	return summary, nil
+}
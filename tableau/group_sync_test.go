@@ -0,0 +1,101 @@
+package tableau
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPlanGroupSync(t *testing.T) {
+	groups := []GroupType{
+		{Id: "analysts", Name: "Analysts"},
+		{Id: "finance", Name: "Finance", Import: ImportDirectiveType{DomainName: "example.com", SiteRole: "Viewer"}},
+	}
+	members := map[ResourceIdType][]UserType{
+		"analysts": {{Id: "u1", Name: "jdoe"}, {Id: "u3", Name: "bjones"}},
+	}
+	users := []UserType{{Id: "u1", Name: "jdoe"}, {Id: "u2", Name: "ASmith"}, {Id: "u3", Name: "bjones"}}
+
+	tests := []struct {
+		name    string
+		groups  []GroupSpec
+		resync  bool
+		changes []string
+	}{
+		{
+			name:    "in sync",
+			groups:  []GroupSpec{{Name: "Analysts", Members: []string{"JDoe", "bjones"}}, {Name: "Finance", Import: &ImportDirectiveSpec{DomainName: "EXAMPLE.com", SiteRole: "Viewer"}}},
+			changes: []string{},
+		},
+		{
+			name:   "members are added and removed",
+			groups: []GroupSpec{{Name: "Analysts", Members: []string{"jdoe", "asmith", "nobody"}}},
+			changes: []string{
+				"+ Analysts: add ASmith",
+				"! Analysts: skip nobody (no such user on the site)",
+				"- Analysts: remove bjones",
+			},
+		},
+		{
+			name:   "new groups",
+			groups: []GroupSpec{{Name: "Sales", Members: []string{"jdoe"}}, {Name: "HR", Import: &ImportDirectiveSpec{DomainName: "example.com", SiteRole: "Viewer"}}},
+			changes: []string{
+				"+ Sales: create",
+				"+ Sales: add jdoe",
+				"~ HR: import from Active Directory domain example.com",
+			},
+		},
+		{
+			name:    "changed import directive",
+			groups:  []GroupSpec{{Name: "Finance", Import: &ImportDirectiveSpec{DomainName: "example.com", SiteRole: "Explorer"}}},
+			changes: []string{"~ Finance: synchronize from Active Directory domain example.com (siteRole: 'Viewer' -> 'Explorer')"},
+		},
+		{
+			name:    "resync",
+			groups:  []GroupSpec{{Name: "Finance", Import: &ImportDirectiveSpec{DomainName: "example.com", SiteRole: "Viewer"}}},
+			resync:  true,
+			changes: []string{"~ Finance: synchronize from Active Directory domain example.com"},
+		},
+		{
+			name:    "import of a local group",
+			groups:  []GroupSpec{{Name: "Analysts", Import: &ImportDirectiveSpec{DomainName: "example.com", SiteRole: "Viewer"}}},
+			resync:  true,
+			changes: []string{"! Analysts: skip (a local group can not be synchronized from Active Directory)"},
+		},
+		{
+			name:    "members of an Active Directory group",
+			groups:  []GroupSpec{{Name: "Finance", Members: []string{"jdoe"}}},
+			changes: []string{"! Finance: skip (members of a group imported from Active Directory domain example.com can not be changed)"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			changes := planGroupSync(GroupMembership{Groups: test.groups}, groups, members, users, test.resync)
+			lines := []string{}
+			for _, change := range changes {
+				lines = append(lines, change.String())
+			}
+			if !reflect.DeepEqual(lines, test.changes) {
+				t.Errorf("plan:\n%q\nexpecting:\n%q", lines, test.changes)
+			}
+		})
+	}
+}
+
+func TestImportDrift(t *testing.T) {
+	tests := []struct {
+		current ImportDirectiveType
+		spec    ImportDirectiveSpec
+		drift   string
+	}{
+		{ImportDirectiveType{DomainName: "example.com", SiteRole: "Viewer"}, ImportDirectiveSpec{DomainName: "Example.COM", SiteRole: "Viewer"}, ""},
+		{ImportDirectiveType{DomainName: "example.com", SiteRole: "Viewer"}, ImportDirectiveSpec{DomainName: "corp.example.com", SiteRole: "Viewer"},
+			"domainName: 'example.com' -> 'corp.example.com'"},
+		{ImportDirectiveType{DomainName: "example.com", SiteRole: "Viewer"}, ImportDirectiveSpec{DomainName: "corp.example.com", SiteRole: "Creator"},
+			"domainName: 'example.com' -> 'corp.example.com', siteRole: 'Viewer' -> 'Creator'"},
+	}
+	for _, test := range tests {
+		if drift := importDrift(test.current, test.spec); drift != test.drift {
+			t.Errorf("importDrift(%+v, %+v) = %q, expecting %q", test.current, test.spec, drift, test.drift)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package tableau
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newTestServer returns a session on a fake tableau server that answers GET requests with the tsResponse body
+// of their path below the site url, e.g. "/workbooks", other paths are answered with an empty tsResponse
+func newTestServer(t *testing.T, responses map[string]string) (*TabGo, func()) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("unexpected %s %s", r.Method, r.URL)
+		}
+		body := responses[strings.TrimPrefix(r.URL.Path, "/api/3.6/sites/site")]
+		w.Write([]byte(`<tsResponse xmlns="http://tableau.com/api">` + body + `</tsResponse>`))
+	}))
+	return &TabGo{ServerURL: server.URL, ApiVersion: "3.6", CurrentSiteID: "site"}, server.Close
+}
+
+// newRecordingServer returns a session on a fake tableau server that records every request as
+// "<method> <path below the site url> <body>" and answers it with status and an empty tsResponse
+func newRecordingServer(t *testing.T, status int) (*TabGo, *[]string, func()) {
+	requests := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("can not read request body: %v", err)
+		}
+		requests = append(requests, strings.TrimSpace(r.Method+" "+strings.TrimPrefix(r.URL.Path, "/api/3.6/sites/site")+" "+string(body)))
+		w.WriteHeader(status)
+		w.Write([]byte(`<tsResponse xmlns="http://tableau.com/api" />`))
+	}))
+	return &TabGo{ServerURL: server.URL, ApiVersion: "3.6", CurrentSiteID: "site"}, &requests, server.Close
+}
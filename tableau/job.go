@@ -0,0 +1,124 @@
+package tableau
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// background job statuses
+const (
+	JobStatusPending    Status = "Pending"
+	JobStatusInProgress Status = "InProgress"
+	JobStatusSuccess    Status = "Success"
+	JobStatusFailed     Status = "Failed"
+	JobStatusCancelled  Status = "Cancelled"
+)
+
+// finish codes of a completed job
+const (
+	FinishCodeSuccess   = 0
+	FinishCodeFailed    = 1
+	FinishCodeCancelled = 2
+)
+
+// ListJobs returns the background jobs of the current site matching the (optional) filter expression,
+// e.g. "status:eq:InProgress" or "jobType:eq:refresh_extracts"
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_jobstasksschedules.htm#query_jobs
+func (tabl *TabGo) ListJobs(filter string) ([]BackgroundJobType, error) {
+	jobs := []BackgroundJobType{}
+	err := tabl.forEachPage(tabl.listURI("jobs", filter), func(tsResponse TsResponse) int {
+		jobs = append(jobs, tsResponse.BackgroundJobs.BackgroundJob...)
+		return len(tsResponse.BackgroundJobs.BackgroundJob)
+	})
+	if err != nil {
+		return jobs, errors.Wrapf(err, "can not list jobs")
+	}
+	return jobs, nil
+}
+
+// QueryJob returns the details of a job, including its progress, finish code and status notes
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_jobstasksschedules.htm#query_job
+func (tabl *TabGo) QueryJob(jobID string) (JobType, error) {
+	tsResponse, err := tabl.doTsRequest("GET", fmt.Sprintf("%s/jobs/%s", tabl.SiteURL(), jobID), "")
+	if err != nil {
+		return tsResponse.Job, errors.Wrapf(err, "can not query job '%s'", jobID)
+	}
+	return tsResponse.Job, nil
+}
+
+// CancelJob cancels a pending or running job
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_jobstasksschedules.htm#cancel_job
+func (tabl *TabGo) CancelJob(jobID string) error {
+	_, err := tabl.doRequest("PUT", fmt.Sprintf("%s/jobs/%s", tabl.SiteURL(), jobID), "")
+	if err != nil {
+		return errors.Wrapf(err, "can not cancel job '%s'", jobID)
+	}
+	return nil
+}
+
+// IsJobCompleted tells whether a job has finished, successfully or not, cfr FinishCodeSuccess
+func IsJobCompleted(job JobType) bool {
+	return !job.CompletedAt.IsZero()
+}
+
+// ErrJobTimeout is returned by WaitForJob when the job did not complete in time
+var ErrJobTimeout = errors.New("timeout waiting for job")
+
+// WaitForJob polls a job every interval until it completes and returns the completed job,
+// a zero timeout waits forever, otherwise ErrJobTimeout is returned with the last state of the job
+func (tabl *TabGo) WaitForJob(jobID string, interval, timeout time.Duration) (JobType, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		job, err := tabl.QueryJob(jobID)
+		if err != nil || IsJobCompleted(job) {
+			return job, err
+		}
+		if timeout > 0 && time.Now().Add(interval).After(deadline) {
+			return job, ErrJobTimeout
+		}
+		time.Sleep(interval)
+	}
+}
+
+// DescribeFinishCode returns a readable description of the finish code of a completed job
+func DescribeFinishCode(finishCode int) string {
+	switch finishCode {
+	case FinishCodeSuccess:
+		return "succeeded"
+	case FinishCodeFailed:
+		return "failed"
+	case FinishCodeCancelled:
+		return "cancelled"
+	}
+	return fmt.Sprintf("finished with code %d", finishCode)
+}
+
+// DescribeJobContent returns what a job works on, e.g. "datasource Sales", or "" when tableau does not say
+func DescribeJobContent(job JobType) string {
+	refresh := job.ExtractRefreshJob
+	parts := []string{}
+	switch {
+	case refresh.Datasource.Id != "":
+		parts = append(parts, "datasource "+nameOrID(refresh.Datasource.Name, refresh.Datasource.Id))
+	case refresh.Workbook.Id != "":
+		parts = append(parts, "workbook "+nameOrID(refresh.Workbook.Name, refresh.Workbook.Id))
+	case job.RunFlowJobType.Flow.Id != "":
+		parts = append(parts, "flow "+nameOrID(job.RunFlowJobType.Flow.Name, job.RunFlowJobType.Flow.Id))
+	}
+	for _, notes := range []string{refresh.Notes, job.RunFlowJobType.Notes} {
+		if notes != "" {
+			parts = append(parts, notes)
+		}
+	}
+	return strings.Join(parts, ": ")
+}
+
+func nameOrID(name string, id ResourceIdType) string {
+	if name != "" {
+		return name
+	}
+	return string(id)
+}
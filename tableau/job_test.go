@@ -0,0 +1,54 @@
+package tableau
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDescribeJob(t *testing.T) {
+	tests := []struct {
+		job     JobType
+		finish  string
+		content string
+	}{
+		{JobType{}, "succeeded", ""},
+		{JobType{FinishCode: FinishCodeFailed, ExtractRefreshJob: ExtractRefreshJobType{Datasource: DataSourceType{Id: "d1", Name: "Sales"}, Notes: "connection refused"}},
+			"failed", "datasource Sales: connection refused"},
+		{JobType{FinishCode: FinishCodeCancelled, ExtractRefreshJob: ExtractRefreshJobType{Workbook: WorkbookType{Id: "w1"}}},
+			"cancelled", "workbook w1"},
+		{JobType{FinishCode: 7, RunFlowJobType: RunFlowJobType{Flow: FlowType{Id: "f1", Name: "Cleanup"}, Notes: "step 2"}},
+			"finished with code 7", "flow Cleanup: step 2"},
+	}
+	for _, test := range tests {
+		if finish := DescribeFinishCode(test.job.FinishCode); finish != test.finish {
+			t.Errorf("DescribeFinishCode(%d) = %q, expecting %q", test.job.FinishCode, finish, test.finish)
+		}
+		if content := DescribeJobContent(test.job); content != test.content {
+			t.Errorf("DescribeJobContent(%+v) = %q, expecting %q", test.job, content, test.content)
+		}
+	}
+}
+
+func TestWaitForJob(t *testing.T) {
+	tabl, cleanup := newTestServer(t, map[string]string{
+		"/jobs/done":    `<job id="done" progress="100" finishCode="1" completedAt="2020-03-01T10:00:00Z" />`,
+		"/jobs/running": `<job id="running" progress="40" />`,
+	})
+	defer cleanup()
+
+	job, err := tabl.WaitForJob("done", time.Millisecond, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsJobCompleted(job) || job.FinishCode != FinishCodeFailed {
+		t.Errorf("job = %+v, expecting a failed completed job", job)
+	}
+
+	job, err = tabl.WaitForJob("running", time.Millisecond, 5*time.Millisecond)
+	if err != ErrJobTimeout {
+		t.Errorf("err = %v, expecting %v", err, ErrJobTimeout)
+	}
+	if IsJobCompleted(job) || job.Progress != 40 {
+		t.Errorf("job = %+v, expecting the running job", job)
+	}
+}
@@ -0,0 +1,166 @@
+package tableau
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// MetadataURL returns the url of the graphql endpoint of the metadata api
+// cfr https://help.tableau.com/current/api/metadata_api/en-us/docs/meta_api_start.html
+func (tabl *TabGo) MetadataURL() string {
+	return fmt.Sprintf("%s/api/metadata/graphql", tabl.ServerURL)
+}
+
+// GraphQLError is an error the metadata api reports next to (partial) data
+type GraphQLError struct {
+	Message string        `json:"message"`
+	Path    []interface{} `json:"path,omitempty"`
+}
+
+// QueryMetadata sends a graphql query with its (optional) variables to the metadata api of the signed in site
+// and json decodes the data of the response into data, pass a *json.RawMessage to get the data as is.
+// An error is returned when the metadata api reports errors.
+func (tabl *TabGo) QueryMetadata(query string, variables map[string]interface{}, data interface{}) error {
+	payload, err := json.Marshal(struct {
+		Query     string                 `json:"query"`
+		Variables map[string]interface{} `json:"variables,omitempty"`
+	}{query, variables})
+	if err != nil {
+		return errors.Wrapf(err, "can not json marshal graphql query")
+	}
+
+	req, err := http.NewRequest("POST", tabl.MetadataURL(), bytes.NewReader(payload))
+	if err != nil {
+		return errors.Wrapf(err, "can not create POST request for '%s'", tabl.MetadataURL())
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-tableau-auth", tabl.CurrentToken)
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "can not client.Do(request) POST '%s'", tabl.MetadataURL())
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrapf(err, "can not read response body")
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("POST '%s' failed with status %d: %s", tabl.MetadataURL(), resp.StatusCode, string(body))
+	}
+
+	response := struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []GraphQLError  `json:"errors"`
+	}{}
+	if err = json.Unmarshal(body, &response); err != nil {
+		return errors.Wrapf(err, "can not json unmarshall graphql response '%s'", string(body))
+	}
+	if len(response.Errors) > 0 {
+		messages := []string{}
+		for _, graphQLError := range response.Errors {
+			messages = append(messages, graphQLError.Message)
+		}
+		return fmt.Errorf("graphql query failed: %s", strings.Join(messages, "; "))
+	}
+	if err = json.Unmarshal(response.Data, data); err != nil {
+		return errors.Wrapf(err, "can not json unmarshall graphql data '%s'", string(response.Data))
+	}
+	return nil
+}
+
+// LineageContent is a workbook or datasource downstream of a database asset, as found by the metadata api
+type LineageContent struct {
+	// ID is the id of the content in the rest api, empty for a datasource embedded in a workbook
+	ID          string `json:"luid"`
+	Name        string `json:"name"`
+	ProjectName string `json:"projectName"`
+	Owner       struct {
+		Username string `json:"username"`
+	} `json:"owner"`
+	// Workbook is the workbook of an embedded datasource
+	Workbook *LineageContent `json:"workbook,omitempty"`
+}
+
+func (content LineageContent) String() string {
+	if content.Workbook != nil {
+		return fmt.Sprintf("%s (embedded in %s)", content.Name, content.Workbook)
+	}
+	return fmt.Sprintf("%s/%s (%s, owner %s)", content.ProjectName, content.Name, content.ID, content.Owner.Username)
+}
+
+// lineageFields are the graphql fields of the workbooks and datasources decoded into LineageContent
+const lineageFields = `
+	downstreamWorkbooks { luid name projectName owner { username } }
+	downstreamDatasources {
+		name
+		... on PublishedDatasource { luid projectName owner { username } }
+		... on EmbeddedDatasource { workbook { luid name projectName owner { username } } }
+	}`
+
+// TableLineage is a database table with the workbooks and datasources that use it
+type TableLineage struct {
+	Name     string `json:"name"`
+	Schema   string `json:"schema"`
+	FullName string `json:"fullName"`
+	Database struct {
+		Name           string `json:"name"`
+		ConnectionType string `json:"connectionType"`
+	} `json:"database"`
+	Workbooks   []LineageContent `json:"downstreamWorkbooks"`
+	Datasources []LineageContent `json:"downstreamDatasources"`
+}
+
+// TableLineage returns the database tables with the given name and the workbooks and datasources that use them
+// cfr https://help.tableau.com/current/api/metadata_api/en-us/reference/databasetable.doc.html
+func (tabl *TabGo) TableLineage(tableName string) ([]TableLineage, error) {
+	data := struct {
+		Tables []TableLineage `json:"databaseTables"`
+	}{}
+	err := tabl.QueryMetadata(`query tableLineage($name: String) {
+	databaseTables(filter: {name: $name}) {
+		name schema fullName
+		database { name connectionType }`+lineageFields+`
+	}
+}`, map[string]interface{}{"name": tableName}, &data)
+	if err != nil {
+		return data.Tables, errors.Wrapf(err, "can not query lineage of table '%s'", tableName)
+	}
+	return data.Tables, nil
+}
+
+// ServerLineage is a database server with the workbooks and datasources that connect to it
+type ServerLineage struct {
+	Name           string           `json:"name"`
+	HostName       string           `json:"hostName"`
+	Port           int              `json:"port"`
+	ConnectionType string           `json:"connectionType"`
+	Workbooks      []LineageContent `json:"downstreamWorkbooks"`
+	Datasources    []LineageContent `json:"downstreamDatasources"`
+}
+
+// ServerLineage returns the database servers with the given host name and the workbooks and datasources that connect to them
+// cfr https://help.tableau.com/current/api/metadata_api/en-us/reference/databaseserver.doc.html
+func (tabl *TabGo) ServerLineage(hostName string) ([]ServerLineage, error) {
+	data := struct {
+		Servers []ServerLineage `json:"databaseServers"`
+	}{}
+	err := tabl.QueryMetadata(`query serverLineage($hostName: String) {
+	databaseServers(filter: {hostName: $hostName}) {
+		name hostName port connectionType`+lineageFields+`
+	}
+}`, map[string]interface{}{"hostName": hostName}, &data)
+	if err != nil {
+		return data.Servers, errors.Wrapf(err, "can not query lineage of database server '%s'", hostName)
+	}
+	return data.Servers, nil
+}
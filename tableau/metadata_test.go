@@ -0,0 +1,68 @@
+package tableau
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newMetadataServer returns a session on a fake metadata api that checks the graphql request and answers with response
+func newMetadataServer(t *testing.T, response string) (*TabGo, func()) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/api/metadata/graphql" || r.Header.Get("X-tableau-auth") != "token" {
+			t.Errorf("unexpected %s %s (auth '%s')", r.Method, r.URL, r.Header.Get("X-tableau-auth"))
+		}
+		request := struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.Query == "" {
+			t.Errorf("invalid graphql request (%v)", err)
+		}
+		w.Write([]byte(response))
+	}))
+	return &TabGo{ServerURL: server.URL, CurrentToken: "token"}, server.Close
+}
+
+func TestTableLineage(t *testing.T) {
+	tabl, cleanup := newMetadataServer(t, `{"data": {"databaseTables": [{
+		"name": "ORDERS", "schema": "DWH", "fullName": "[DWH].[ORDERS]",
+		"database": {"name": "dwh", "connectionType": "oracle"},
+		"downstreamWorkbooks": [{"luid": "w1", "name": "Sales", "projectName": "Finance", "owner": {"username": "jdoe"}}],
+		"downstreamDatasources": [
+			{"luid": "d1", "name": "Orders", "projectName": "Finance", "owner": {"username": "asmith"}},
+			{"name": "Embedded orders", "workbook": {"luid": "w2", "name": "Margin", "projectName": "Finance", "owner": {"username": "jdoe"}}}
+		]}]}}`)
+	defer cleanup()
+
+	tables, err := tabl.TableLineage("ORDERS")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tables) != 1 || tables[0].FullName != "[DWH].[ORDERS]" || tables[0].Database.ConnectionType != "oracle" {
+		t.Fatalf("tables = %+v, expecting [DWH].[ORDERS] of oracle", tables)
+	}
+	lineage := []string{}
+	for _, content := range append(tables[0].Workbooks, tables[0].Datasources...) {
+		lineage = append(lineage, content.String())
+	}
+	expected := "Finance/Sales (w1, owner jdoe)\n" +
+		"Finance/Orders (d1, owner asmith)\n" +
+		"Embedded orders (embedded in Finance/Margin (w2, owner jdoe))"
+	if strings.Join(lineage, "\n") != expected {
+		t.Errorf("lineage:\n%s\nexpecting:\n%s", strings.Join(lineage, "\n"), expected)
+	}
+}
+
+func TestQueryMetadataErrors(t *testing.T) {
+	tabl, cleanup := newMetadataServer(t, `{"data": null, "errors": [{"message": "no field foo"}, {"message": "no field bar"}]}`)
+	defer cleanup()
+
+	var data json.RawMessage
+	err := tabl.QueryMetadata("{ foo bar }", nil, &data)
+	if err == nil || err.Error() != "graphql query failed: no field foo; no field bar" {
+		t.Errorf("err = %v, expecting the graphql errors", err)
+	}
+}
@@ -0,0 +1,367 @@
+package tableau
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// MigrationMapping maps the project paths and owners of a source site to the ones of a target site.
+// Projects maps a source project path to a target project path, the longest matching source path wins
+// and nested projects keep their place below it. Unmapped projects and owners keep their path and name.
+// Example yaml:
+//
+//	projects:
+//	  Staging/Finance: Finance
+//	owners:
+//	  jdoe: john.doe
+type MigrationMapping struct {
+	Projects map[string]string `yaml:"projects,omitempty"`
+	Owners   map[string]string `yaml:"owners,omitempty"`
+}
+
+// ReadMigrationMapping reads a migration mapping from a yaml file
+func ReadMigrationMapping(path string) (MigrationMapping, error) {
+	mapping := MigrationMapping{}
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return mapping, errors.Wrapf(err, "can not read %s", path)
+	}
+	err = yaml.UnmarshalStrict(content, &mapping)
+	if err != nil {
+		return mapping, errors.Wrapf(err, "can not yaml decode %s", path)
+	}
+	return mapping, nil
+}
+
+// TargetProject returns the target project path of a source project path
+func (mapping MigrationMapping) TargetProject(sourcePath string) string {
+	names := SplitProjectPath(sourcePath)
+	for i := len(names); i > 0; i-- {
+		for from, to := range mapping.Projects {
+			if JoinProjectPath(names[:i]...) == JoinProjectPath(SplitProjectPath(from)...) {
+				return JoinProjectPath(append(SplitProjectPath(to), names[i:]...)...)
+			}
+		}
+	}
+	return JoinProjectPath(names...)
+}
+
+// TargetOwner returns the target user name of a source user name
+func (mapping MigrationMapping) TargetOwner(sourceName string) string {
+	if name, found := mapping.Owners[sourceName]; found {
+		return name
+	}
+	return sourceName
+}
+
+// MigrationItem is a workbook or datasource to migrate
+type MigrationItem struct {
+	Kind          ContentKind
+	ID            string
+	Name          string
+	Description   string
+	Tags          []string
+	SourceProject string
+	TargetProject string
+	SourceOwner   string
+	TargetOwner   string
+}
+
+func (item MigrationItem) String() string {
+	line := fmt.Sprintf("%s %s: %s -> %s", item.Kind.element(), item.Name, item.SourceProject, item.TargetProject)
+	if item.SourceOwner != item.TargetOwner {
+		line += fmt.Sprintf(" (owner %s -> %s)", item.SourceOwner, item.TargetOwner)
+	}
+	return line
+}
+
+// MigrationResult is the outcome of migrating a single item.
+// Warnings are the details (description, tags, owner, permissions) that could not be migrated,
+// Err is set when the item itself could not be migrated.
+type MigrationResult struct {
+	Item     MigrationItem
+	TargetID string
+	Warnings []string
+	Err      error
+}
+
+func (result MigrationResult) String() string {
+	if result.Err != nil {
+		return fmt.Sprintf("%s: failed: %v", result.Item, result.Err)
+	}
+	line := fmt.Sprintf("%s: migrated (%s)", result.Item, result.TargetID)
+	for _, warning := range result.Warnings {
+		line += "\n\twarning: " + warning
+	}
+	return line
+}
+
+// Migration copies workbooks and datasources from a source to a target session, which may be on another site or server.
+// Documents are downloaded from the source and published to the target with PublishDocument,
+// which rewrites their connections with Connections and their repository-location site to the target site.
+// Descriptions, tags, owners and (with Permissions) explicit permissions are copied where the target allows it.
+type Migration struct {
+	Source      *TabGo
+	Target      *TabGo
+	Mapping     MigrationMapping
+	Connections ConnectionFinder
+	// WorkDir holds the downloaded documents while they are migrated, a temporary dir when empty
+	WorkDir        string
+	IncludeExtract bool
+	Permissions    bool
+
+	sourceUsers  map[ResourceIdType]string
+	sourceGroups map[ResourceIdType]string
+	targetUsers  map[string]UserType
+	targetGroups map[string]GroupType
+}
+
+// Plan returns the workbooks and datasources of the source project at projectPath and its nested projects
+// (the whole site when projectPath is empty), datasources first so workbooks can connect to them once published.
+func (migration *Migration) Plan(projectPath string) ([]MigrationItem, error) {
+	items := []MigrationItem{}
+
+	roots, err := migration.Source.GetProjectTree()
+	if err != nil {
+		return items, err
+	}
+	if projectPath != "" {
+		node, err := migration.Source.FindProject(projectPath)
+		if err != nil {
+			return items, err
+		}
+		if node == nil {
+			return items, fmt.Errorf("project '%s' does not exist on site '%s'", projectPath, migration.Source.CurrentSiteName)
+		}
+		roots = []*ProjectNode{node}
+	}
+	projectPaths := make(map[ResourceIdType]string)
+	for _, root := range roots {
+		root.Walk(func(node *ProjectNode, depth int) {
+			projectPaths[node.Project.Id] = node.Path()
+		})
+	}
+
+	if err = migration.loadSourceUsers(); err != nil {
+		return items, err
+	}
+
+	item := func(kind ContentKind, id ResourceIdType, name, description string, tags TagListType, project ProjectType, owner UserType) {
+		sourcePath, found := projectPaths[project.Id]
+		if !found {
+			return
+		}
+		sourceOwner := migration.sourceUsers[owner.Id]
+		items = append(items, MigrationItem{
+			Kind:          kind,
+			ID:            string(id),
+			Name:          name,
+			Description:   description,
+			Tags:          tagLabels(tags),
+			SourceProject: sourcePath,
+			TargetProject: migration.Mapping.TargetProject(sourcePath),
+			SourceOwner:   sourceOwner,
+			TargetOwner:   migration.Mapping.TargetOwner(sourceOwner),
+		})
+	}
+
+	datasources, err := migration.Source.ListDatasources("")
+	if err != nil {
+		return items, err
+	}
+	for _, datasource := range datasources {
+		item(KindDatasource, datasource.Id, datasource.Name, datasource.Description, datasource.Tags, datasource.Project, datasource.Owner)
+	}
+	workbooks, err := migration.Source.ListWorkbooks("")
+	if err != nil {
+		return items, err
+	}
+	for _, workbook := range workbooks {
+		item(KindWorkbook, workbook.Id, workbook.Name, workbook.Description, workbook.Tags, workbook.Project, workbook.Owner)
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Kind != items[j].Kind {
+			return items[i].Kind == KindDatasource
+		}
+		return items[i].SourceProject+"/"+items[i].Name < items[j].SourceProject+"/"+items[j].Name
+	})
+	return items, nil
+}
+
+// Run migrates items in order, a failing item does not stop the migration
+func (migration *Migration) Run(items []MigrationItem) []MigrationResult {
+	results := []MigrationResult{}
+
+	workDir := migration.WorkDir
+	if workDir == "" {
+		tmpDir, err := ioutil.TempDir("", "migrate")
+		if err != nil {
+			for _, item := range items {
+				results = append(results, MigrationResult{Item: item, Err: errors.Wrapf(err, "can not create tmp dir")})
+			}
+			return results
+		}
+		defer os.RemoveAll(tmpDir)
+		workDir = tmpDir
+	}
+
+	for _, item := range items {
+		results = append(results, migration.migrate(item, workDir))
+	}
+	return results
+}
+
+func (migration *Migration) migrate(item MigrationItem, workDir string) MigrationResult {
+	result := MigrationResult{Item: item}
+
+	var path string
+	var err error
+	if item.Kind == KindWorkbook {
+		path, err = migration.Source.DownloadWorkbook(item.ID, item.Name, workDir, migration.IncludeExtract)
+	} else {
+		path, err = migration.Source.DownloadDatasource(item.ID, item.Name, workDir, migration.IncludeExtract)
+	}
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	defer os.Remove(path)
+
+	tsResponse, err := migration.Target.PublishDocument(path, item.TargetProject, migration.Connections)
+	if err != nil {
+		result.Err = errors.Wrapf(err, "can not publish to project '%s'", item.TargetProject)
+		return result
+	}
+	result.TargetID = string(tsResponse.Workbook.Id)
+	if item.Kind == KindDatasource {
+		result.TargetID = string(tsResponse.Datasource.Id)
+	}
+
+	if item.Description != "" {
+		err = migration.Target.updateContent(item.Kind, result.TargetID, fmt.Sprintf(` description="%s"`, xmlEscape(item.Description)), "")
+		if err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("description not migrated: %v", err))
+		}
+	}
+
+	if len(item.Tags) > 0 {
+		if _, err = migration.Target.AddTags(item.Kind, result.TargetID, item.Tags); err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("tags not migrated: %v", err))
+		}
+	}
+
+	if item.TargetOwner != "" {
+		owner, err := migration.Target.GetUserByName(item.TargetOwner)
+		if err == nil {
+			err = migration.Target.UpdateContentOwner(item.Kind, result.TargetID, string(owner.Id))
+		}
+		if err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("owner %s not migrated: %v", item.TargetOwner, err))
+		}
+	}
+
+	if migration.Permissions {
+		result.Warnings = append(result.Warnings, migration.migratePermissions(item, result.TargetID)...)
+	}
+	return result
+}
+
+// migratePermissions copies the explicit permissions of item to targetID, matching groups by name and users by mapped name.
+// It returns a warning for every grantee that could not be matched and when the permissions could not be copied.
+func (migration *Migration) migratePermissions(item MigrationItem, targetID string) []string {
+	warnings := []string{}
+	permissions, err := migration.Source.QueryPermissions(item.Kind, item.ID)
+	if err != nil {
+		return append(warnings, fmt.Sprintf("permissions not migrated: %v", err))
+	}
+	if err = migration.loadGrantees(); err != nil {
+		return append(warnings, fmt.Sprintf("permissions not migrated: %v", err))
+	}
+
+	grantees := []GranteeCapabilitiesType{}
+	for _, grantee := range permissions.GranteeCapabilities {
+		target := GranteeCapabilitiesType{Capabilities: grantee.Capabilities}
+		if grantee.Group.Id != "" {
+			name := migration.sourceGroups[grantee.Group.Id]
+			group, found := migration.targetGroups[name]
+			if !found {
+				warnings = append(warnings, fmt.Sprintf("permissions of group '%s' not migrated: no such group on the target", name))
+				continue
+			}
+			target.Group = GroupType{Id: group.Id}
+		} else {
+			name := migration.Mapping.TargetOwner(migration.sourceUsers[grantee.User.Id])
+			user, found := migration.targetUsers[strings.ToLower(name)]
+			if !found {
+				warnings = append(warnings, fmt.Sprintf("permissions of user '%s' not migrated: no such user on the target", name))
+				continue
+			}
+			target.User = UserType{Id: user.Id}
+		}
+		grantees = append(grantees, target)
+	}
+	if len(grantees) == 0 {
+		return warnings
+	}
+	if _, err = migration.Target.AddPermissions(item.Kind, targetID, grantees); err != nil {
+		warnings = append(warnings, fmt.Sprintf("permissions not migrated: %v", err))
+	}
+	return warnings
+}
+
+func (migration *Migration) loadSourceUsers() error {
+	if migration.sourceUsers != nil {
+		return nil
+	}
+	users, err := migration.Source.ListUsers("")
+	if err != nil {
+		return err
+	}
+	migration.sourceUsers = make(map[ResourceIdType]string)
+	for _, user := range users {
+		migration.sourceUsers[user.Id] = user.Name
+	}
+	return nil
+}
+
+func (migration *Migration) loadGrantees() error {
+	if migration.targetUsers != nil {
+		return nil
+	}
+	if err := migration.loadSourceUsers(); err != nil {
+		return err
+	}
+	sourceGroups, err := migration.Source.ListGroups("")
+	if err != nil {
+		return err
+	}
+	targetGroups, err := migration.Target.ListGroups("")
+	if err != nil {
+		return err
+	}
+	targetUsers, err := migration.Target.ListUsers("")
+	if err != nil {
+		return err
+	}
+
+	migration.sourceGroups = make(map[ResourceIdType]string)
+	for _, group := range sourceGroups {
+		migration.sourceGroups[group.Id] = group.Name
+	}
+	migration.targetGroups = make(map[string]GroupType)
+	for _, group := range targetGroups {
+		migration.targetGroups[group.Name] = group
+	}
+	migration.targetUsers = make(map[string]UserType)
+	for _, user := range targetUsers {
+		migration.targetUsers[strings.ToLower(user.Name)] = user
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package tableau
+
+import "testing"
+
+func TestMigrationMappingTargetProject(t *testing.T) {
+	mapping := MigrationMapping{Projects: map[string]string{
+		"Staging":                  "Archive/Staging",
+		"Staging/Finance":          "Finance",
+		"/Staging/Finance/Legacy/": "Finance/Old",
+		`Sales\/Marketing`:         "Marketing",
+		"Default":                  `Imported\/Default`,
+	}}
+
+	tests := []struct {
+		source string
+		target string
+	}{
+		{"Unmapped", "Unmapped"},
+		{"Unmapped/Nested", "Unmapped/Nested"},
+		{"Staging", "Archive/Staging"},
+		{"Staging/HR", "Archive/Staging/HR"},
+		{"Staging/Finance", "Finance"},
+		{"Staging/Finance/Reports", "Finance/Reports"},
+		{"Staging/Finance/Legacy", "Finance/Old"},
+		{"Staging/Finance/Legacy/2019", "Finance/Old/2019"},
+		{"/Staging/Finance/", "Finance"},
+		{"Staging/Financial", "Archive/Staging/Financial"},
+		{`Sales\/Marketing/Campaigns`, "Marketing/Campaigns"},
+		{"Sales/Marketing", "Sales/Marketing"},
+		{"Default", `Imported\/Default`},
+	}
+	for _, test := range tests {
+		if target := mapping.TargetProject(test.source); target != test.target {
+			t.Errorf("TargetProject(%q) = %q, expecting %q", test.source, target, test.target)
+		}
+	}
+}
+
+func TestMigrationMappingTargetOwner(t *testing.T) {
+	mapping := MigrationMapping{Owners: map[string]string{"jdoe": "john.doe"}}
+	for source, target := range map[string]string{"jdoe": "john.doe", "asmith": "asmith"} {
+		if owner := mapping.TargetOwner(source); owner != target {
+			t.Errorf("TargetOwner(%q) = %q, expecting %q", source, owner, target)
+		}
+	}
+}
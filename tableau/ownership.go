@@ -0,0 +1,64 @@
+package tableau
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// OwnershipChange transfers the ownership of a workbook, datasource, flow or project to another user
+type OwnershipChange struct {
+	Kind ContentKind
+	Id   ResourceIdType
+	Path string
+	From UserType
+	To   UserType
+}
+
+func (change OwnershipChange) String() string {
+	return fmt.Sprintf("~ %s %s: owner %s -> %s", change.Kind.element(), change.Path, change.From.Name, change.To.Name)
+}
+
+// PlanReassign returns the changes transferring all workbooks, datasources, flows and projects of the current site
+// owned by from to to, e.g. when from leaves. Projects come first, in hierarchy order.
+func (tabl *TabGo) PlanReassign(from, to UserType) ([]OwnershipChange, error) {
+	changes := []OwnershipChange{}
+	if from.Id == to.Id {
+		return changes, fmt.Errorf("can not reassign the content of '%s' to the same user", from.Name)
+	}
+
+	roots, err := tabl.cachedProjectTree()
+	if err != nil {
+		return changes, err
+	}
+	for _, root := range roots {
+		root.Walk(func(node *ProjectNode, depth int) {
+			if node.Project.Owner.Id == from.Id {
+				changes = append(changes, OwnershipChange{Kind: KindProject, Id: node.Project.Id, Path: node.Path(), From: from, To: to})
+			}
+		})
+	}
+
+	for _, kind := range []ContentKind{KindWorkbook, KindDatasource, KindFlow} {
+		contents, err := tabl.ListContent(kind, "")
+		if err != nil {
+			return changes, err
+		}
+		for _, content := range contents {
+			if content.Owner.Id == from.Id {
+				changes = append(changes, OwnershipChange{Kind: kind, Id: content.Id, Path: content.Path, From: from, To: to})
+			}
+		}
+	}
+	return changes, nil
+}
+
+// ApplyReassign executes ownership changes in order
+func (tabl *TabGo) ApplyReassign(changes []OwnershipChange) error {
+	for _, change := range changes {
+		if err := tabl.UpdateContentOwner(change.Kind, string(change.Id), string(change.To.Id)); err != nil {
+			return errors.Wrapf(err, "can not apply '%s'", change)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package tableau
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPlanReassign(t *testing.T) {
+	tabl, cleanup := newTestServer(t, map[string]string{
+		"/projects": `<projects>
+			<project id="p2" name="Reports" parentProjectId="p1"><owner id="u1" /></project>
+			<project id="p1" name="Finance"><owner id="u1" /></project>
+			<project id="p3" name="Sales"><owner id="u2" /></project>
+		</projects>`,
+		"/workbooks":   `<workbooks><workbook id="w1" name="Budget"><project id="p2" /><owner id="u1" /></workbook><workbook id="w2" name="Pipeline"><project id="p3" /><owner id="u2" /></workbook></workbooks>`,
+		"/datasources": `<datasources><datasource id="d1" name="Ledger"><project id="p1" /><owner id="u1" /></datasource></datasources>`,
+	})
+	defer cleanup()
+
+	from, to := UserType{Id: "u1", Name: "jdoe"}, UserType{Id: "u3", Name: "asmith"}
+	changes, err := tabl.PlanReassign(from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan := []string{}
+	for _, change := range changes {
+		plan = append(plan, change.String())
+	}
+	expected := []string{
+		"~ project Finance: owner jdoe -> asmith",
+		"~ project Finance/Reports: owner jdoe -> asmith",
+		"~ workbook Finance/Reports/Budget: owner jdoe -> asmith",
+		"~ datasource Finance/Ledger: owner jdoe -> asmith",
+	}
+	if !reflect.DeepEqual(plan, expected) {
+		t.Errorf("plan = %q, expecting %q", plan, expected)
+	}
+
+	if _, err := tabl.PlanReassign(from, from); err == nil {
+		t.Error("expecting an error reassigning content to its owner")
+	}
+}
@@ -0,0 +1,250 @@
+package tableau
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// ContentKind is the kind of a tableau resource, as used in rest api urls
+type ContentKind string
+
+const (
+	KindProject    ContentKind = "projects"
+	KindWorkbook   ContentKind = "workbooks"
+	KindDatasource ContentKind = "datasources"
+	KindFlow       ContentKind = "flows"
+	KindView       ContentKind = "views"
+)
+
+// capability modes
+const (
+	ModeAllow Mode = "Allow"
+	ModeDeny  Mode = "Deny"
+)
+
+// QueryPermissions returns the explicit permissions of a project, workbook, datasource, flow or view
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_permissions.htm#query_project_permissions
+func (tabl *TabGo) QueryPermissions(kind ContentKind, id string) (PermissionsType, error) {
+	tsResponse, err := tabl.doTsRequest("GET", tabl.permissionsURI(kind, id), "")
+	if err != nil {
+		return tsResponse.Permissions, errors.Wrapf(err, "can not query permissions of %s '%s'", kind, id)
+	}
+	return tsResponse.Permissions, nil
+}
+
+// AddPermissions adds capabilities for groups and/or users to a project, workbook, datasource, flow or view
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_permissions.htm#add_project_permissions
+func (tabl *TabGo) AddPermissions(kind ContentKind, id string, grantees []GranteeCapabilitiesType) (PermissionsType, error) {
+	tsResponse, err := tabl.doTsRequest("PUT", tabl.permissionsURI(kind, id), permissionsPayload(grantees))
+	if err != nil {
+		return tsResponse.Permissions, errors.Wrapf(err, "can not add permissions to %s '%s'", kind, id)
+	}
+	return tsResponse.Permissions, nil
+}
+
+// DeletePermissions removes all capabilities of grantee from a project, workbook, datasource, flow or view
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_permissions.htm#delete_project_permission
+func (tabl *TabGo) DeletePermissions(kind ContentKind, id string, grantee GranteeCapabilitiesType) error {
+	for _, capability := range grantee.Capabilities.Capability {
+		uri := fmt.Sprintf("%s/%s/%s/%s", tabl.permissionsURI(kind, id), granteePath(grantee), capability.Name, capability.Mode)
+		if _, err := tabl.doRequest("DELETE", uri, ""); err != nil {
+			return errors.Wrapf(err, "can not delete permission %s %s of %s '%s'", capability.Name, capability.Mode, kind, id)
+		}
+	}
+	return nil
+}
+
+// QueryDefaultPermissions returns the default permissions a project gives to new content of the given kind
+// (workbooks, datasources or flows)
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_permissions.htm#query_default_permissions
+func (tabl *TabGo) QueryDefaultPermissions(projectID string, kind ContentKind) (PermissionsType, error) {
+	tsResponse, err := tabl.doTsRequest("GET", tabl.defaultPermissionsURI(projectID, kind), "")
+	if err != nil {
+		return tsResponse.Permissions, errors.Wrapf(err, "can not query default %s permissions of project '%s'", kind, projectID)
+	}
+	return tsResponse.Permissions, nil
+}
+
+// AddDefaultPermissions adds default capabilities for new content of the given kind (workbooks, datasources or flows) in a project
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_permissions.htm#add_default_permissions
+func (tabl *TabGo) AddDefaultPermissions(projectID string, kind ContentKind, grantees []GranteeCapabilitiesType) (PermissionsType, error) {
+	tsResponse, err := tabl.doTsRequest("PUT", tabl.defaultPermissionsURI(projectID, kind), permissionsPayload(grantees))
+	if err != nil {
+		return tsResponse.Permissions, errors.Wrapf(err, "can not add default %s permissions to project '%s'", kind, projectID)
+	}
+	return tsResponse.Permissions, nil
+}
+
+// DeleteDefaultPermissions removes all default capabilities of grantee for content of the given kind in a project
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_permissions.htm#delete_default_permission
+func (tabl *TabGo) DeleteDefaultPermissions(projectID string, kind ContentKind, grantee GranteeCapabilitiesType) error {
+	for _, capability := range grantee.Capabilities.Capability {
+		uri := fmt.Sprintf("%s/%s/%s/%s", tabl.defaultPermissionsURI(projectID, kind), granteePath(grantee), capability.Name, capability.Mode)
+		if _, err := tabl.doRequest("DELETE", uri, ""); err != nil {
+			return errors.Wrapf(err, "can not delete default %s permission %s %s of project '%s'", kind, capability.Name, capability.Mode, projectID)
+		}
+	}
+	return nil
+}
+
+func (tabl *TabGo) permissionsURI(kind ContentKind, id string) string {
+	return fmt.Sprintf("%s/%s/%s/permissions", tabl.SiteURL(), kind, id)
+}
+
+func (tabl *TabGo) defaultPermissionsURI(projectID string, kind ContentKind) string {
+	return fmt.Sprintf("%s/projects/%s/default-permissions/%s", tabl.SiteURL(), projectID, kind)
+}
+
+// granteePath returns the url path of the group or user of grantee, e.g. "groups/<group id>"
+func granteePath(grantee GranteeCapabilitiesType) string {
+	if grantee.Group.Id != "" {
+		return fmt.Sprintf("groups/%s", grantee.Group.Id)
+	}
+	return fmt.Sprintf("users/%s", grantee.User.Id)
+}
+
+func permissionsPayload(grantees []GranteeCapabilitiesType) string {
+	payload := ""
+	for _, grantee := range grantees {
+		granteeElement := fmt.Sprintf(`<user id="%s" />`, grantee.User.Id)
+		if grantee.Group.Id != "" {
+			granteeElement = fmt.Sprintf(`<group id="%s" />`, grantee.Group.Id)
+		}
+		capabilities := ""
+		for _, capability := range grantee.Capabilities.Capability {
+			capabilities += fmt.Sprintf(`<capability name="%s" mode="%s" />`, capability.Name, capability.Mode)
+		}
+		payload += fmt.Sprintf(`<granteeCapabilities>%s<capabilities>%s</capabilities></granteeCapabilities>`, granteeElement, capabilities)
+	}
+	return fmt.Sprintf(`<tsRequest><permissions>%s</permissions></tsRequest>`, payload)
+}
+
+// PermissionRule grants capabilities to a group or a user, by name
+// Example yaml:
+//
+//	group: Analysts
+//	capabilities:
+//	  Read: Allow
+//	  ExportData: Deny
+type PermissionRule struct {
+	Group        string        `yaml:"group,omitempty"`
+	User         string        `yaml:"user,omitempty"`
+	Capabilities map[Name]Mode `yaml:"capabilities"`
+}
+
+// PermissionTemplate describes the permissions of a project and the default permissions for the content within it.
+// Example yaml:
+//
+//	contentPermissions: LockedToProject
+//	project:
+//	  - group: Analysts
+//	    capabilities: {Read: Allow}
+//	workbooks:
+//	  - group: Analysts
+//	    capabilities: {Read: Allow, ExportData: Deny}
+type PermissionTemplate struct {
+	ContentPermissions ContentPermissions `yaml:"contentPermissions,omitempty"`
+	Project            []PermissionRule   `yaml:"project,omitempty"`
+	Workbooks          []PermissionRule   `yaml:"workbooks,omitempty"`
+	Datasources        []PermissionRule   `yaml:"datasources,omitempty"`
+	Flows              []PermissionRule   `yaml:"flows,omitempty"`
+}
+
+// ReadPermissionTemplate reads a permission template from a yaml file
+func ReadPermissionTemplate(path string) (PermissionTemplate, error) {
+	template := PermissionTemplate{}
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return template, errors.Wrapf(err, "can not read %s", path)
+	}
+	err = yaml.UnmarshalStrict(content, &template)
+	if err != nil {
+		return template, errors.Wrapf(err, "can not yaml decode %s", path)
+	}
+	return template, nil
+}
+
+// ResolvePermissionRules looks up the groups and users of rules on the current site
+// and returns them as grantee capabilities
+func (tabl *TabGo) ResolvePermissionRules(rules []PermissionRule) ([]GranteeCapabilitiesType, error) {
+	grantees := []GranteeCapabilitiesType{}
+	for _, rule := range rules {
+		grantee := GranteeCapabilitiesType{}
+		switch {
+		case rule.Group != "" && rule.User != "":
+			return grantees, fmt.Errorf("permission rule for group '%s' and user '%s', expecting either a group or a user", rule.Group, rule.User)
+		case rule.Group != "":
+			group, err := tabl.GetGroupByName(rule.Group)
+			if err != nil {
+				return grantees, err
+			}
+			grantee.Group = group
+		case rule.User != "":
+			user, err := tabl.GetUserByName(rule.User)
+			if err != nil {
+				return grantees, err
+			}
+			grantee.User = user
+		default:
+			return grantees, fmt.Errorf("permission rule without group or user")
+		}
+		grantee.Capabilities = rule.capabilities()
+		grantees = append(grantees, grantee)
+	}
+	return grantees, nil
+}
+
+// capabilities returns the capabilities of the rule, sorted by name
+func (rule PermissionRule) capabilities() Capabilities {
+	capabilities := Capabilities{}
+	for name, mode := range rule.Capabilities {
+		capabilities.Capability = append(capabilities.Capability, CapabilityType{Name: name, Mode: mode})
+	}
+	sort.Slice(capabilities.Capability, func(i, j int) bool {
+		return capabilities.Capability[i].Name < capabilities.Capability[j].Name
+	})
+	return capabilities
+}
+
+// ApplyPermissionTemplate sets the content permissions mode of a project
+// and adds the project and default permissions of the template to it
+func (tabl *TabGo) ApplyPermissionTemplate(projectID string, template PermissionTemplate) error {
+	if template.ContentPermissions != "" {
+		if _, err := tabl.UpdateProject(projectID, "", nil, template.ContentPermissions); err != nil {
+			return err
+		}
+	}
+
+	if len(template.Project) > 0 {
+		grantees, err := tabl.ResolvePermissionRules(template.Project)
+		if err != nil {
+			return errors.Wrapf(err, "can not resolve project permissions")
+		}
+		if _, err = tabl.AddPermissions(KindProject, projectID, grantees); err != nil {
+			return err
+		}
+	}
+
+	defaults := map[ContentKind][]PermissionRule{
+		KindWorkbook:   template.Workbooks,
+		KindDatasource: template.Datasources,
+		KindFlow:       template.Flows,
+	}
+	for _, kind := range []ContentKind{KindWorkbook, KindDatasource, KindFlow} {
+		if len(defaults[kind]) == 0 {
+			continue
+		}
+		grantees, err := tabl.ResolvePermissionRules(defaults[kind])
+		if err != nil {
+			return errors.Wrapf(err, "can not resolve default %s permissions", kind)
+		}
+		if _, err = tabl.AddDefaultPermissions(projectID, kind, grantees); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,246 @@
+package tableau
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// ProjectPermissions are the desired permissions of the project at Path (cfr SplitProjectPath).
+// Only the scopes present in the file are managed: an omitted scope (e.g. no "flows") is left as is on the server,
+// an empty scope ("flows: []") removes all of its capabilities.
+type ProjectPermissions struct {
+	Path               string `yaml:"path"`
+	PermissionTemplate `yaml:",inline"`
+}
+
+// PermissionsSpec maps project paths to their desired permissions
+// Example yaml:
+//
+//	projects:
+//	  - path: Finance/Reports
+//	    contentPermissions: LockedToProject
+//	    project:
+//	      - group: Analysts
+//	        capabilities: {Read: Allow, Write: Deny}
+//	    workbooks:
+//	      - group: Analysts
+//	        capabilities: {Read: Allow, ExportData: Allow}
+type PermissionsSpec struct {
+	Projects []ProjectPermissions `yaml:"projects"`
+}
+
+// ReadPermissionsSpec reads the desired permissions from a yaml file
+func ReadPermissionsSpec(path string) (PermissionsSpec, error) {
+	spec := PermissionsSpec{}
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return spec, errors.Wrapf(err, "can not read %s", path)
+	}
+	err = yaml.UnmarshalStrict(content, &spec)
+	if err != nil {
+		return spec, errors.Wrapf(err, "can not yaml decode %s", path)
+	}
+	return spec, nil
+}
+
+// PermissionAction is what a PermissionChange does on the server
+type PermissionAction string
+
+const (
+	PermissionAdd                PermissionAction = "add"
+	PermissionDelete             PermissionAction = "delete"
+	PermissionContentPermissions PermissionAction = "contentPermissions"
+)
+
+// PermissionChange is a single difference between the desired and the actual permissions of a project
+type PermissionChange struct {
+	Action      PermissionAction
+	ProjectPath string
+	ProjectID   string
+	// Scope is KindProject for the permissions of the project itself,
+	// the kind of content (workbooks, datasources, flows) for the default permissions of the project
+	Scope ContentKind
+	// Grantee holds the group or user and the single capability to add or delete
+	Grantee     GranteeCapabilitiesType
+	GranteeName string
+	// ContentPermissions is the desired content permissions mode of a PermissionContentPermissions change
+	ContentPermissions ContentPermissions
+}
+
+func (change PermissionChange) String() string {
+	switch change.Action {
+	case PermissionContentPermissions:
+		return fmt.Sprintf("~ %s: contentPermissions -> %s", change.ProjectPath, change.ContentPermissions)
+	case PermissionAdd, PermissionDelete:
+		symbol := map[PermissionAction]string{PermissionAdd: "+", PermissionDelete: "-"}[change.Action]
+		scope := "project"
+		if change.Scope != KindProject {
+			scope = fmt.Sprintf("default %s", change.Scope)
+		}
+		capability := change.Grantee.Capabilities.Capability[0]
+		return fmt.Sprintf("%s %s (%s): %s %s %s", symbol, change.ProjectPath, scope, change.GranteeName, capability.Name, capability.Mode)
+	}
+	return fmt.Sprintf("? %s: %s", change.ProjectPath, change.Action)
+}
+
+// PlanPermissionsSync compares the desired permissions with the permissions on the current site
+// and returns the changes needed to make the site match them.
+// Deletes of a project scope come before its adds, so a capability can change mode.
+func (tabl *TabGo) PlanPermissionsSync(spec PermissionsSpec) ([]PermissionChange, error) {
+	changes := []PermissionChange{}
+	names := make(map[string]string)
+
+	for _, projectPermissions := range spec.Projects {
+		node, err := tabl.FindProject(projectPermissions.Path)
+		if err != nil {
+			return changes, err
+		}
+		if node == nil {
+			return changes, fmt.Errorf("project '%s' does not exist on site '%s'", projectPermissions.Path, tabl.CurrentSiteName)
+		}
+		projectID := string(node.Project.Id)
+
+		if projectPermissions.ContentPermissions != "" && projectPermissions.ContentPermissions != node.Project.ContentPermissions {
+			changes = append(changes, PermissionChange{
+				Action:             PermissionContentPermissions,
+				ProjectPath:        projectPermissions.Path,
+				ProjectID:          projectID,
+				ContentPermissions: projectPermissions.ContentPermissions,
+			})
+		}
+
+		scopes := map[ContentKind][]PermissionRule{
+			KindProject:    projectPermissions.Project,
+			KindWorkbook:   projectPermissions.Workbooks,
+			KindDatasource: projectPermissions.Datasources,
+			KindFlow:       projectPermissions.Flows,
+		}
+		for _, scope := range []ContentKind{KindProject, KindWorkbook, KindDatasource, KindFlow} {
+			rules := scopes[scope]
+			if rules == nil {
+				continue
+			}
+			desired, err := tabl.ResolvePermissionRules(rules)
+			if err != nil {
+				return changes, errors.Wrapf(err, "can not resolve %s permissions of project '%s'", scope, projectPermissions.Path)
+			}
+			for i, rule := range rules {
+				names[granteePath(desired[i])] = rule.granteeName()
+			}
+
+			var actual PermissionsType
+			if scope == KindProject {
+				actual, err = tabl.QueryPermissions(KindProject, projectID)
+			} else {
+				actual, err = tabl.QueryDefaultPermissions(projectID, scope)
+			}
+			if err != nil {
+				return changes, err
+			}
+
+			for _, change := range diffPermissions(desired, actual.GranteeCapabilities) {
+				change.ProjectPath = projectPermissions.Path
+				change.ProjectID = projectID
+				change.Scope = scope
+				change.GranteeName = names[granteePath(change.Grantee)]
+				if change.GranteeName == "" {
+					change.GranteeName = granteePath(change.Grantee)
+				}
+				changes = append(changes, change)
+			}
+		}
+	}
+	return changes, nil
+}
+
+func (rule PermissionRule) granteeName() string {
+	if rule.Group != "" {
+		return "group " + rule.Group
+	}
+	return "user " + rule.User
+}
+
+// diffPermissions returns the deletes and adds (in that order) that turn the actual into the desired capabilities,
+// every change holds a single capability
+func diffPermissions(desired, actual []GranteeCapabilitiesType) []PermissionChange {
+	type capabilityKey struct {
+		grantee string
+		name    Name
+	}
+	flatten := func(grantees []GranteeCapabilitiesType) (map[capabilityKey]Mode, map[string]GranteeCapabilitiesType, []capabilityKey) {
+		modes := make(map[capabilityKey]Mode)
+		byKey := make(map[string]GranteeCapabilitiesType)
+		keys := []capabilityKey{}
+		for _, grantee := range grantees {
+			byKey[granteePath(grantee)] = grantee
+			for _, capability := range grantee.Capabilities.Capability {
+				key := capabilityKey{grantee: granteePath(grantee), name: capability.Name}
+				if _, found := modes[key]; !found {
+					keys = append(keys, key)
+				}
+				modes[key] = capability.Mode
+			}
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			if keys[i].grantee != keys[j].grantee {
+				return keys[i].grantee < keys[j].grantee
+			}
+			return keys[i].name < keys[j].name
+		})
+		return modes, byKey, keys
+	}
+	single := func(grantee GranteeCapabilitiesType, name Name, mode Mode) GranteeCapabilitiesType {
+		return GranteeCapabilitiesType{
+			Group:        GroupType{Id: grantee.Group.Id, Name: grantee.Group.Name},
+			User:         UserType{Id: grantee.User.Id, Name: grantee.User.Name},
+			Capabilities: Capabilities{Capability: []CapabilityType{{Name: name, Mode: mode}}},
+		}
+	}
+
+	desiredModes, desiredGrantees, desiredKeys := flatten(desired)
+	actualModes, actualGrantees, actualKeys := flatten(actual)
+
+	changes := []PermissionChange{}
+	for _, key := range actualKeys {
+		if desiredModes[key] != actualModes[key] {
+			changes = append(changes, PermissionChange{Action: PermissionDelete, Grantee: single(actualGrantees[key.grantee], key.name, actualModes[key])})
+		}
+	}
+	for _, key := range desiredKeys {
+		if desiredModes[key] != actualModes[key] {
+			changes = append(changes, PermissionChange{Action: PermissionAdd, Grantee: single(desiredGrantees[key.grantee], key.name, desiredModes[key])})
+		}
+	}
+	return changes
+}
+
+// ApplyPermissionsSync executes the changes of a permissions sync plan, in order
+func (tabl *TabGo) ApplyPermissionsSync(changes []PermissionChange) error {
+	for _, change := range changes {
+		var err error
+		switch change.Action {
+		case PermissionContentPermissions:
+			_, err = tabl.UpdateProject(change.ProjectID, "", nil, change.ContentPermissions)
+		case PermissionAdd:
+			if change.Scope == KindProject {
+				_, err = tabl.AddPermissions(KindProject, change.ProjectID, []GranteeCapabilitiesType{change.Grantee})
+			} else {
+				_, err = tabl.AddDefaultPermissions(change.ProjectID, change.Scope, []GranteeCapabilitiesType{change.Grantee})
+			}
+		case PermissionDelete:
+			if change.Scope == KindProject {
+				err = tabl.DeletePermissions(KindProject, change.ProjectID, change.Grantee)
+			} else {
+				err = tabl.DeleteDefaultPermissions(change.ProjectID, change.Scope, change.Grantee)
+			}
+		}
+		if err != nil {
+			return errors.Wrapf(err, "can not apply '%s'", change)
+		}
+	}
+	return nil
+}
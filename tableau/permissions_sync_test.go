@@ -0,0 +1,77 @@
+package tableau
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffPermissions(t *testing.T) {
+	grantee := func(path string, capabilities ...CapabilityType) GranteeCapabilitiesType {
+		granteeCapabilities := GranteeCapabilitiesType{Capabilities: Capabilities{Capability: capabilities}}
+		switch path[0] {
+		case 'g':
+			granteeCapabilities.Group = GroupType{Id: ResourceIdType(path)}
+		default:
+			granteeCapabilities.User = UserType{Id: ResourceIdType(path)}
+		}
+		return granteeCapabilities
+	}
+	allow := func(name Name) CapabilityType { return CapabilityType{Name: name, Mode: ModeAllow} }
+	deny := func(name Name) CapabilityType { return CapabilityType{Name: name, Mode: ModeDeny} }
+
+	tests := []struct {
+		name    string
+		desired []GranteeCapabilitiesType
+		actual  []GranteeCapabilitiesType
+		changes []string
+	}{
+		{
+			name:    "no permissions",
+			changes: []string{},
+		},
+		{
+			name:    "up to date, in another order",
+			desired: []GranteeCapabilitiesType{grantee("g1", allow("Read"), deny("ExportData")), grantee("u1", allow("Read"))},
+			actual:  []GranteeCapabilitiesType{grantee("u1", allow("Read")), grantee("g1", deny("ExportData"), allow("Read"))},
+			changes: []string{},
+		},
+		{
+			name:    "add",
+			desired: []GranteeCapabilitiesType{grantee("g1", allow("Read"), allow("Write"))},
+			actual:  []GranteeCapabilitiesType{grantee("g1", allow("Read"))},
+			changes: []string{"add groups/g1 Write Allow"},
+		},
+		{
+			name:    "delete",
+			desired: []GranteeCapabilitiesType{grantee("g1", allow("Read"))},
+			actual:  []GranteeCapabilitiesType{grantee("g1", allow("Read")), grantee("u1", allow("Write"))},
+			changes: []string{"delete users/u1 Write Allow"},
+		},
+		{
+			name:    "changed mode deletes before it adds",
+			desired: []GranteeCapabilitiesType{grantee("g1", deny("Read")), grantee("g2", allow("Write"))},
+			actual:  []GranteeCapabilitiesType{grantee("g2", deny("Write")), grantee("g1", allow("Read"))},
+			changes: []string{
+				"delete groups/g1 Read Allow",
+				"delete groups/g2 Write Deny",
+				"add groups/g1 Read Deny",
+				"add groups/g2 Write Allow",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			lines := []string{}
+			for _, change := range diffPermissions(test.desired, test.actual) {
+				if len(change.Grantee.Capabilities.Capability) != 1 {
+					t.Fatalf("expecting a single capability per change, got %v", change.Grantee.Capabilities.Capability)
+				}
+				capability := change.Grantee.Capabilities.Capability[0]
+				lines = append(lines, string(change.Action)+" "+granteePath(change.Grantee)+" "+string(capability.Name)+" "+string(capability.Mode))
+			}
+			if !reflect.DeepEqual(lines, test.changes) {
+				t.Errorf("changes:\n%q\nexpecting:\n%q", lines, test.changes)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package tableau
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPermissionsPayload(t *testing.T) {
+	rule := PermissionRule{Group: "Analysts", Capabilities: map[Name]Mode{"Read": ModeAllow, "ExportData": ModeDeny, "Filter": ModeAllow}}
+	grantees := []GranteeCapabilitiesType{
+		{Group: GroupType{Id: "g1"}, Capabilities: rule.capabilities()},
+		{User: UserType{Id: "u1"}, Capabilities: Capabilities{Capability: []CapabilityType{{Name: "Read", Mode: ModeAllow}}}},
+	}
+	expected := `<tsRequest><permissions>` +
+		`<granteeCapabilities><group id="g1" /><capabilities>` +
+		`<capability name="ExportData" mode="Deny" /><capability name="Filter" mode="Allow" /><capability name="Read" mode="Allow" />` +
+		`</capabilities></granteeCapabilities>` +
+		`<granteeCapabilities><user id="u1" /><capabilities><capability name="Read" mode="Allow" /></capabilities></granteeCapabilities>` +
+		`</permissions></tsRequest>`
+	if payload := permissionsPayload(grantees); payload != expected {
+		t.Errorf("payload:\n%s\nexpecting:\n%s", payload, expected)
+	}
+	for grantee, path := range map[int]string{0: "groups/g1", 1: "users/u1"} {
+		if granteePath(grantees[grantee]) != path {
+			t.Errorf("granteePath(%+v) = %s, expecting %s", grantees[grantee], granteePath(grantees[grantee]), path)
+		}
+	}
+}
+
+func TestResolvePermissionRules(t *testing.T) {
+	tabl, cleanup := newTestServer(t, map[string]string{
+		"/groups": `<groups><group id="g1" name="Analysts" /></groups>`,
+		"/users":  `<users><user id="u1" name="jdoe" /></users>`,
+	})
+	defer cleanup()
+
+	tests := []struct {
+		name  string
+		rule  PermissionRule
+		group ResourceIdType
+		user  ResourceIdType
+		error string
+	}{
+		{"group", PermissionRule{Group: "Analysts", Capabilities: map[Name]Mode{"Read": ModeAllow}}, "g1", "", ""},
+		{"user", PermissionRule{User: "jdoe", Capabilities: map[Name]Mode{"Read": ModeAllow}}, "", "u1", ""},
+		{"unknown group", PermissionRule{Group: "Finance"}, "", "", "no group 'Finance' found"},
+		{"group and user", PermissionRule{Group: "Analysts", User: "jdoe"}, "", "", "expecting either a group or a user"},
+		{"no grantee", PermissionRule{}, "", "", "permission rule without group or user"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			grantees, err := tabl.ResolvePermissionRules([]PermissionRule{test.rule})
+			if test.error != "" {
+				if err == nil || !strings.Contains(err.Error(), test.error) {
+					t.Fatalf("error '%v' does not contain '%s'", err, test.error)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(grantees) != 1 || grantees[0].Group.Id != test.group || grantees[0].User.Id != test.user {
+				t.Fatalf("grantees = %+v, expecting group '%s' or user '%s'", grantees, test.group, test.user)
+			}
+			if !reflect.DeepEqual(grantees[0].Capabilities, test.rule.capabilities()) {
+				t.Errorf("capabilities = %+v, expecting %+v", grantees[0].Capabilities, test.rule.capabilities())
+			}
+		})
+	}
+}
+
+func TestUpdateProjectResetsProjectTree(t *testing.T) {
+	description := "finance"
+	for status, reset := range map[int]bool{200: true, 500: false} {
+		tabl, _, cleanup := newRecordingServer(t, status)
+		tabl.projectTree = ProjectTree([]ProjectType{{Id: "p1", Name: "Finance"}})
+		_, err := tabl.UpdateProject("p1", "", &description, "")
+		if (err == nil) != (status == 200) {
+			t.Errorf("status %d: unexpected error %v", status, err)
+		}
+		if (tabl.projectTree == nil) != reset {
+			t.Errorf("status %d: project tree reset = %t, expecting %t", status, tabl.projectTree == nil, reset)
+		}
+		cleanup()
+	}
+}
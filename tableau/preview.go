@@ -0,0 +1,178 @@
+package tableau
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// previewMetadataFile is the name of the metadata file of the previews of a workbook, cfr SaveWorkbookPreviews
+const previewMetadataFile = "metadata.json"
+
+// WorkbookPreview describes the saved preview images of a workbook and its views
+type WorkbookPreview struct {
+	WorkbookID  string        `json:"workbookId"`
+	Name        string        `json:"name"`
+	ProjectID   string        `json:"projectId"`
+	ProjectName string        `json:"projectName"`
+	ContentURL  string        `json:"contentUrl"`
+	WebpageURL  string        `json:"webpageUrl,omitempty"`
+	UpdatedAt   time.Time     `json:"updatedAt"`
+	SavedAt     time.Time     `json:"savedAt"`
+	Image       PreviewImage  `json:"image"`
+	Views       []ViewPreview `json:"views"`
+}
+
+// ViewPreview describes the saved preview image of a view
+type ViewPreview struct {
+	ViewID     string       `json:"viewId"`
+	Name       string       `json:"name"`
+	ContentURL string       `json:"contentUrl"`
+	Image      PreviewImage `json:"image"`
+}
+
+// PreviewImage is a saved preview image, Changed tells whether it differs from the image saved before
+type PreviewImage struct {
+	File    string `json:"file"`
+	SHA256  string `json:"sha256"`
+	Changed bool   `json:"changed"`
+}
+
+// WorkbookPreviewImage returns the preview image (png) of a workbook
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_workbooksviews.htm#query_workbook_preview_image
+func (tabl *TabGo) WorkbookPreviewImage(workbookID string) ([]byte, error) {
+	image, err := tabl.doRequest("GET", fmt.Sprintf("%s/workbooks/%s/previewImage", tabl.SiteURL(), workbookID), "")
+	if err != nil {
+		return nil, errors.Wrapf(err, "can not get preview image of workbook '%s'", workbookID)
+	}
+	return image, nil
+}
+
+// ViewPreviewImage returns the preview image (png) of a view of a workbook
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_workbooksviews.htm#query_view_preview_image
+func (tabl *TabGo) ViewPreviewImage(workbookID, viewID string) ([]byte, error) {
+	image, err := tabl.doRequest("GET", fmt.Sprintf("%s/workbooks/%s/views/%s/previewImage", tabl.SiteURL(), workbookID, viewID), "")
+	if err != nil {
+		return nil, errors.Wrapf(err, "can not get preview image of view '%s'", viewID)
+	}
+	return image, nil
+}
+
+// SaveWorkbookPreviews saves the preview images of a workbook and its views, keyed by their ids, in dir:
+// <dir>/<workbook id>/preview.png, <dir>/<workbook id>/views/<view id>.png and <dir>/<workbook id>/metadata.json.
+// The metadata describes the workbook and its views and tells which images changed since they were saved before,
+// the images of views that no longer exist are removed.
+func (tabl *TabGo) SaveWorkbookPreviews(workbookID, dir string) (WorkbookPreview, error) {
+	preview := WorkbookPreview{}
+	workbook, err := tabl.QueryWorkbook(workbookID)
+	if err != nil {
+		return preview, err
+	}
+	views, err := tabl.ListWorkbookViews(workbookID)
+	if err != nil {
+		return preview, err
+	}
+
+	workbookDir := filepath.Join(dir, workbookID)
+	if err = os.MkdirAll(filepath.Join(workbookDir, "views"), 0755); err != nil {
+		return preview, errors.Wrapf(err, "can not create %s", workbookDir)
+	}
+	previous := readWorkbookPreview(workbookDir)
+
+	preview = WorkbookPreview{
+		WorkbookID:  string(workbook.Id),
+		Name:        workbook.Name,
+		ProjectID:   string(workbook.Project.Id),
+		ProjectName: workbook.Project.Name,
+		ContentURL:  workbook.ContentUrl,
+		WebpageURL:  workbook.WebpageUrl,
+		UpdatedAt:   workbook.UpdatedAt,
+		SavedAt:     time.Now().UTC(),
+		Views:       []ViewPreview{},
+	}
+
+	image, err := tabl.WorkbookPreviewImage(workbookID)
+	if err != nil {
+		return preview, err
+	}
+	if preview.Image, err = savePreviewImage(workbookDir, "preview.png", image, previous.Image.SHA256); err != nil {
+		return preview, err
+	}
+
+	previousViews := make(map[string]string)
+	for _, view := range previous.Views {
+		previousViews[view.ViewID] = view.Image.SHA256
+	}
+	current := make(map[string]bool)
+	for _, view := range views {
+		image, err := tabl.ViewPreviewImage(workbookID, string(view.Id))
+		if err != nil {
+			return preview, err
+		}
+		file := filepath.Join("views", string(view.Id)+".png")
+		viewImage, err := savePreviewImage(workbookDir, file, image, previousViews[string(view.Id)])
+		if err != nil {
+			return preview, err
+		}
+		preview.Views = append(preview.Views, ViewPreview{ViewID: string(view.Id), Name: view.Name, ContentURL: view.ContentUrl, Image: viewImage})
+		current[file] = true
+	}
+
+	for _, view := range previous.Views {
+		if !current[view.Image.File] && strings.HasPrefix(view.Image.File, "views") {
+			_ = os.Remove(filepath.Join(workbookDir, view.Image.File))
+		}
+	}
+
+	content, err := json.MarshalIndent(preview, "", "  ")
+	if err != nil {
+		return preview, errors.Wrapf(err, "can not json encode preview of workbook '%s'", workbookID)
+	}
+	metadataPath := filepath.Join(workbookDir, previewMetadataFile)
+	if err = ioutil.WriteFile(metadataPath, content, 0644); err != nil {
+		return preview, errors.Wrapf(err, "can not write %s", metadataPath)
+	}
+	return preview, nil
+}
+
+// readWorkbookPreview returns the metadata saved before in workbookDir, or an empty preview when there is none
+func readWorkbookPreview(workbookDir string) WorkbookPreview {
+	previous := WorkbookPreview{}
+	content, err := ioutil.ReadFile(filepath.Join(workbookDir, previewMetadataFile))
+	if err == nil {
+		_ = json.NewDecoder(bytes.NewReader(content)).Decode(&previous)
+	}
+	return previous
+}
+
+// savePreviewImage writes image to file within dir and compares its hash with the previous hash
+func savePreviewImage(dir, file string, image []byte, previousSHA256 string) (PreviewImage, error) {
+	hash := sha256.Sum256(image)
+	saved := PreviewImage{File: file, SHA256: hex.EncodeToString(hash[:])}
+	saved.Changed = saved.SHA256 != previousSHA256
+	path := filepath.Join(dir, file)
+	if err := ioutil.WriteFile(path, image, 0644); err != nil {
+		return saved, errors.Wrapf(err, "can not write %s", path)
+	}
+	return saved, nil
+}
+
+// ChangedViews returns the names of the views whose preview image changed, cfr SaveWorkbookPreviews
+func (preview WorkbookPreview) ChangedViews() []string {
+	changed := []string{}
+	for _, view := range preview.Views {
+		if view.Image.Changed {
+			changed = append(changed, view.Name)
+		}
+	}
+	return changed
+}
@@ -0,0 +1,78 @@
+package tableau
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSaveWorkbookPreviews(t *testing.T) {
+	// the fake server answers the paths below the site url, previewImage paths with the bytes of the image
+	site := map[string]string{
+		"/workbooks/w1": `<workbook id="w1" name="Sales" contentUrl="Sales"><project id="p1" name="Finance" /></workbook>`,
+		"/workbooks/w1/views": `<views><view id="v1" name="Overview" contentUrl="Sales/sheets/Overview" />` +
+			`<view id="v2" name="Details" contentUrl="Sales/sheets/Details" /></views>`,
+		"/workbooks/w1/previewImage":          "workbook",
+		"/workbooks/w1/views/v1/previewImage": "overview",
+		"/workbooks/w1/views/v2/previewImage": "details",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/api/3.6/sites/site")
+		body, found := site[path]
+		switch {
+		case !found:
+			w.WriteHeader(http.StatusNotFound)
+		case strings.HasSuffix(path, "/previewImage"):
+			w.Write([]byte(body))
+		default:
+			w.Write([]byte(`<tsResponse xmlns="http://tableau.com/api">` + body + `</tsResponse>`))
+		}
+	}))
+	defer server.Close()
+	tabl := &TabGo{ServerURL: server.URL, ApiVersion: "3.6", CurrentSiteID: "site"}
+
+	dir, err := ioutil.TempDir("", "tabgo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	preview, err := tabl.SaveWorkbookPreviews("w1", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if preview.Name != "Sales" || preview.ProjectName != "Finance" || !preview.Image.Changed {
+		t.Errorf("first preview = %+v, expecting a changed preview of Sales in Finance", preview)
+	}
+	if changed := preview.ChangedViews(); !reflect.DeepEqual(changed, []string{"Overview", "Details"}) {
+		t.Errorf("changed views of the first preview = %q, expecting all views", changed)
+	}
+
+	// the second time only the image of Overview changed and Details is gone
+	site["/workbooks/w1/views"] = `<views><view id="v1" name="Overview" contentUrl="Sales/sheets/Overview" /></views>`
+	site["/workbooks/w1/views/v1/previewImage"] = "overview, updated"
+	preview, err = tabl.SaveWorkbookPreviews("w1", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if preview.Image.Changed {
+		t.Errorf("workbook image = %+v, expecting an unchanged image", preview.Image)
+	}
+	if changed := preview.ChangedViews(); !reflect.DeepEqual(changed, []string{"Overview"}) {
+		t.Errorf("changed views of the second preview = %q, expecting Overview", changed)
+	}
+	if image, err := ioutil.ReadFile(filepath.Join(dir, "w1", "views", "v1.png")); err != nil || string(image) != "overview, updated" {
+		t.Errorf("saved image of Overview = %q (%v)", image, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "w1", "views", "v2.png")); !os.IsNotExist(err) {
+		t.Errorf("image of the removed view Details still exists (%v)", err)
+	}
+	if saved := readWorkbookPreview(filepath.Join(dir, "w1")); !reflect.DeepEqual(saved.Views, preview.Views) {
+		t.Errorf("saved views = %+v, expecting %+v", saved.Views, preview.Views)
+	}
+}
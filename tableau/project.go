@@ -0,0 +1,398 @@
+package tableau
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// content permission modes of a project
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_projects.htm#create_project
+const (
+	ContentPermissionsManagedByOwner               ContentPermissions = "ManagedByOwner"
+	ContentPermissionsLockedToProject              ContentPermissions = "LockedToProject"
+	ContentPermissionsLockedToProjectWithoutNested ContentPermissions = "LockedToProjectWithoutNested"
+)
+
+// ProjectNode is a project within the project hierarchy of a site
+type ProjectNode struct {
+	Project  ProjectType
+	Parent   *ProjectNode
+	Children []*ProjectNode
+}
+
+// Path returns the names of the project and all of its ancestors as a project path, cfr SplitProjectPath
+func (node *ProjectNode) Path() string {
+	if node.Parent == nil {
+		return EscapeProjectName(node.Project.Name)
+	}
+	return node.Parent.Path() + "/" + EscapeProjectName(node.Project.Name)
+}
+
+// Depth is the number of ancestors of the project, 0 for a top level project
+func (node *ProjectNode) Depth() int {
+	if node.Parent == nil {
+		return 0
+	}
+	return node.Parent.Depth() + 1
+}
+
+// EscapeProjectName escapes "/" (and "\") in a project name, so it can be used as a segment of a project path
+func EscapeProjectName(name string) string {
+	return strings.NewReplacer(`\`, `\\`, "/", `\/`).Replace(name)
+}
+
+// JoinProjectPath returns the project path of the given project names, cfr SplitProjectPath
+func JoinProjectPath(names ...string) string {
+	escaped := make([]string, len(names))
+	for i, name := range names {
+		escaped[i] = EscapeProjectName(name)
+	}
+	return strings.Join(escaped, "/")
+}
+
+// SplitProjectPath splits a project path ("Parent/Child/Grandchild") into project names.
+// A "/" that is part of a project name is escaped as "\/", a "\" as "\\".
+// Empty names (e.g. of a leading or trailing "/") are skipped.
+func SplitProjectPath(path string) []string {
+	names := []string{}
+	name := strings.Builder{}
+	escaped := false
+	for _, r := range path {
+		switch {
+		case escaped:
+			name.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '/':
+			if name.Len() > 0 {
+				names = append(names, name.String())
+			}
+			name.Reset()
+		default:
+			name.WriteRune(r)
+		}
+	}
+	if name.Len() > 0 {
+		names = append(names, name.String())
+	}
+	return names
+}
+
+// Child returns the direct child project with the given name, nil if there is none
+func (node *ProjectNode) Child(name string) *ProjectNode {
+	for _, child := range node.Children {
+		if child.Project.Name == name {
+			return child
+		}
+	}
+	return nil
+}
+
+// Walk calls visit for the node and all of its descendants, depth first
+func (node *ProjectNode) Walk(visit func(node *ProjectNode, depth int)) {
+	node.walk(visit, 0)
+}
+
+func (node *ProjectNode) walk(visit func(node *ProjectNode, depth int), depth int) {
+	visit(node, depth)
+	for _, child := range node.Children {
+		child.walk(visit, depth+1)
+	}
+}
+
+// ProjectTree arranges a flat list of projects into their hierarchy and returns the top level projects.
+// Projects whose parent is not in the list are considered top level.
+// Siblings are sorted by name.
+func ProjectTree(projects []ProjectType) []*ProjectNode {
+	nodes := make(map[ResourceIdType]*ProjectNode, len(projects))
+	for _, project := range projects {
+		nodes[project.Id] = &ProjectNode{Project: project}
+	}
+
+	roots := []*ProjectNode{}
+	for _, project := range projects {
+		node := nodes[project.Id]
+		if parent, found := nodes[project.ParentProjectId]; found && project.ParentProjectId != "" {
+			node.Parent = parent
+			parent.Children = append(parent.Children, node)
+			continue
+		}
+		roots = append(roots, node)
+	}
+
+	sortProjectNodes(roots)
+	for _, node := range nodes {
+		sortProjectNodes(node.Children)
+	}
+	return roots
+}
+
+func sortProjectNodes(nodes []*ProjectNode) {
+	sort.Slice(nodes, func(i, j int) bool {
+		return strings.ToLower(nodes[i].Project.Name) < strings.ToLower(nodes[j].Project.Name)
+	})
+}
+
+// ListProjects returns all projects of the current site
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_projects.htm#query_projects
+func (tabl *TabGo) ListProjects() ([]ProjectType, error) {
+	projects := []ProjectType{}
+	err := tabl.forEachPage(fmt.Sprintf("%s/projects", tabl.SiteURL()), func(tsResponse TsResponse) int {
+		projects = append(projects, tsResponse.Projects.Project...)
+		return len(tsResponse.Projects.Project)
+	})
+	if err != nil {
+		return projects, errors.Wrapf(err, "can not list projects")
+	}
+	return projects, nil
+}
+
+// GetProjectTree returns the top level projects of the current site, with all nested projects as their children
+func (tabl *TabGo) GetProjectTree() ([]*ProjectNode, error) {
+	projects, err := tabl.ListProjects()
+	if err != nil {
+		return nil, err
+	}
+	return ProjectTree(projects), nil
+}
+
+// cachedProjectTree returns the project hierarchy of the current site, it is only read from the server once per session
+func (tabl *TabGo) cachedProjectTree() ([]*ProjectNode, error) {
+	if tabl.projectTree != nil {
+		return tabl.projectTree, nil
+	}
+	roots, err := tabl.GetProjectTree()
+	if err != nil {
+		return nil, err
+	}
+	tabl.projectTree = roots
+	return roots, nil
+}
+
+// FindProject returns the project at projectPath (cfr SplitProjectPath), nil when there is no such project.
+// Unlike GetProjectID it never creates projects, it shares its cached project hierarchy though.
+func (tabl *TabGo) FindProject(projectPath string) (*ProjectNode, error) {
+	roots, err := tabl.cachedProjectTree()
+	if err != nil {
+		return nil, errors.Wrapf(err, "can not get projects")
+	}
+	var node *ProjectNode
+	siblings := roots
+	for _, name := range SplitProjectPath(projectPath) {
+		node = findProjectNode(siblings, name)
+		if node == nil {
+			return nil, nil
+		}
+		siblings = node.Children
+	}
+	return node, nil
+}
+
+// cachedProjectNode returns the node of the project with the given id in the cached project hierarchy, nil if it is not there
+func (tabl *TabGo) cachedProjectNode(projectID ResourceIdType) *ProjectNode {
+	var found *ProjectNode
+	for _, root := range tabl.projectTree {
+		root.Walk(func(node *ProjectNode, depth int) {
+			if node.Project.Id == projectID {
+				found = node
+			}
+		})
+	}
+	return found
+}
+
+// cacheProject adds a newly created project to the cached project hierarchy (if not there yet) and returns its node
+func (tabl *TabGo) cacheProject(project ProjectType) *ProjectNode {
+	if node := tabl.cachedProjectNode(project.Id); node != nil {
+		return node
+	}
+	node := &ProjectNode{Project: project}
+	if tabl.projectTree == nil {
+		return node
+	}
+	if project.ParentProjectId == "" {
+		tabl.projectTree = append(tabl.projectTree, node)
+		return node
+	}
+	parent := tabl.cachedProjectNode(project.ParentProjectId)
+	if parent == nil {
+		// the parent is unknown, the cache can no longer be trusted
+		tabl.projectTree = nil
+		return node
+	}
+	node.Parent = parent
+	parent.Children = append(parent.Children, node)
+	return node
+}
+
+// CreateProjectWithDetails creates a project with the name, description, parentProjectId and contentPermissions of project.
+// An empty ParentProjectId creates a top level project.
+// When TabGo.NewProjectPermissions is set, that permission template is applied to the new project.
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_projects.htm#create_project
+func (tabl *TabGo) CreateProjectWithDetails(project ProjectType) (ProjectType, error) {
+	var template PermissionTemplate
+	if tabl.NewProjectPermissions != nil {
+		template = *tabl.NewProjectPermissions
+		if project.ContentPermissions == "" {
+			project.ContentPermissions = template.ContentPermissions
+		}
+		template.ContentPermissions = ""
+	}
+
+	attributes := fmt.Sprintf(`name="%s" description="%s"`, xmlEscape(project.Name), xmlEscape(project.Description))
+	if project.ParentProjectId != "" {
+		attributes += fmt.Sprintf(` parentProjectId="%s"`, project.ParentProjectId)
+	}
+	if project.ContentPermissions != "" {
+		attributes += fmt.Sprintf(` contentPermissions="%s"`, project.ContentPermissions)
+	}
+
+	tsResponse, err := tabl.doTsRequest("POST", fmt.Sprintf("%s/projects", tabl.SiteURL()),
+		fmt.Sprintf(`<tsRequest><project %s /></tsRequest>`, attributes))
+	if err != nil {
+		return tsResponse.Project, errors.Wrapf(err, "can not create project '%s'", project.Name)
+	}
+	if tsResponse.Project.ParentProjectId == "" {
+		tsResponse.Project.ParentProjectId = project.ParentProjectId
+	}
+	tabl.cacheProject(tsResponse.Project)
+
+	if tabl.NewProjectPermissions != nil {
+		err = tabl.ApplyPermissionTemplate(string(tsResponse.Project.Id), template)
+		if err != nil {
+			return tsResponse.Project, errors.Wrapf(err, "can not apply permission template to project '%s'", project.Name)
+		}
+	}
+	return tsResponse.Project, nil
+}
+
+// UpdateProject changes the name, description and/or content permissions of a project,
+// an empty name or content permissions and a nil description are left unchanged on the server,
+// an empty description clears it.
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_projects.htm#update_project
+func (tabl *TabGo) UpdateProject(projectID, name string, description *string, contentPermissions ContentPermissions) (ProjectType, error) {
+	attributes := ""
+	if name != "" {
+		attributes += fmt.Sprintf(` name="%s"`, xmlEscape(name))
+	}
+	if description != nil {
+		attributes += fmt.Sprintf(` description="%s"`, xmlEscape(*description))
+	}
+	if contentPermissions != "" {
+		attributes += fmt.Sprintf(` contentPermissions="%s"`, contentPermissions)
+	}
+	return tabl.updateProject(projectID, attributes, "")
+}
+
+// MoveProject changes the parent of a project, an empty parentProjectID moves the project to the top level
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_projects.htm#update_project
+func (tabl *TabGo) MoveProject(projectID, parentProjectID string) (ProjectType, error) {
+	return tabl.updateProject(projectID, fmt.Sprintf(` parentProjectId="%s"`, parentProjectID), "")
+}
+
+// UpdateProjectOwner makes the user with id ownerID the owner of a project
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_projects.htm#update_project
+func (tabl *TabGo) UpdateProjectOwner(projectID, ownerID string) (ProjectType, error) {
+	return tabl.updateProject(projectID, "", fmt.Sprintf(`<owner id="%s" />`, ownerID))
+}
+
+func (tabl *TabGo) updateProject(projectID, attributes, elements string) (ProjectType, error) {
+	tsResponse, err := tabl.doTsRequest("PUT", fmt.Sprintf("%s/projects/%s", tabl.SiteURL(), projectID),
+		fmt.Sprintf(`<tsRequest><project%s>%s</project></tsRequest>`, attributes, elements))
+	if err != nil {
+		return tsResponse.Project, errors.Wrapf(err, "can not update project '%s'", projectID)
+	}
+	tabl.projectTree = nil
+	return tsResponse.Project, nil
+}
+
+// ProjectContent summarizes what is stored directly within a project
+type ProjectContent struct {
+	Projects    []ProjectType
+	Workbooks   []WorkbookType
+	Datasources []DataSourceType
+	Flows       []FlowType
+}
+
+// IsEmpty is true when the project contains no nested projects, workbooks, datasources or flows
+func (content ProjectContent) IsEmpty() bool {
+	return len(content.Projects) == 0 && len(content.Workbooks) == 0 && len(content.Datasources) == 0 && len(content.Flows) == 0
+}
+
+func (content ProjectContent) String() string {
+	return fmt.Sprintf("%d projects, %d workbooks, %d datasources, %d flows",
+		len(content.Projects), len(content.Workbooks), len(content.Datasources), len(content.Flows))
+}
+
+// GetProjectContent returns the nested projects, workbooks, datasources and flows directly within a project
+func (tabl *TabGo) GetProjectContent(projectID string) (ProjectContent, error) {
+	content := ProjectContent{}
+
+	projects, err := tabl.ListProjects()
+	if err != nil {
+		return content, err
+	}
+	for _, project := range projects {
+		if string(project.ParentProjectId) == projectID {
+			content.Projects = append(content.Projects, project)
+		}
+	}
+
+	workbooks, err := tabl.ListWorkbooks("")
+	if err != nil {
+		return content, err
+	}
+	for _, workbook := range workbooks {
+		if string(workbook.Project.Id) == projectID {
+			content.Workbooks = append(content.Workbooks, workbook)
+		}
+	}
+
+	datasources, err := tabl.ListDatasources("")
+	if err != nil {
+		return content, err
+	}
+	for _, datasource := range datasources {
+		if string(datasource.Project.Id) == projectID {
+			content.Datasources = append(content.Datasources, datasource)
+		}
+	}
+
+	flows, err := tabl.ListFlows("")
+	if err != nil {
+		return content, err
+	}
+	for _, flow := range flows {
+		if string(flow.Project.Id) == projectID {
+			content.Flows = append(content.Flows, flow)
+		}
+	}
+
+	return content, nil
+}
+
+// DeleteProject deletes a project and, on the server, everything within it.
+// Unless force is set, a project that still contains projects, workbooks, datasources or flows is not deleted.
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_projects.htm#delete_project
+func (tabl *TabGo) DeleteProject(projectID string, force bool) error {
+	if !force {
+		content, err := tabl.GetProjectContent(projectID)
+		if err != nil {
+			return errors.Wrapf(err, "can not verify that project '%s' is empty", projectID)
+		}
+		if !content.IsEmpty() {
+			return fmt.Errorf("project '%s' is not empty (%s), refusing to delete it", projectID, content)
+		}
+	}
+
+	_, err := tabl.doRequest("DELETE", fmt.Sprintf("%s/projects/%s", tabl.SiteURL(), projectID), "")
+	if err != nil {
+		return errors.Wrapf(err, "can not delete project '%s'", projectID)
+	}
+	tabl.projectTree = nil
+	return nil
+}
@@ -0,0 +1,396 @@
+package tableau
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// defaultProjectName is the project every site has, it can not be deleted
+const defaultProjectName = "Default"
+
+// ProjectSpec is the desired state of a project (and its nested projects) in a project hierarchy file.
+// Name may be a project path ("Finance/Reports", cfr SplitProjectPath),
+// missing intermediate projects are created without details.
+// Empty values are not managed: they are neither compared with nor written to the server.
+type ProjectSpec struct {
+	Name               string             `yaml:"name"`
+	Description        string             `yaml:"description,omitempty"`
+	ContentPermissions ContentPermissions `yaml:"contentPermissions,omitempty"`
+	Owner              string             `yaml:"owner,omitempty"`
+	Projects           []ProjectSpec      `yaml:"projects,omitempty"`
+}
+
+// ProjectHierarchy is the desired project tree of a site
+// Example yaml:
+//
+//	projects:
+//	  - name: Finance
+//	    description: financial reporting
+//	    contentPermissions: LockedToProject
+//	    owner: jdoe
+//	    projects:
+//	      - name: Reports
+type ProjectHierarchy struct {
+	Projects []ProjectSpec `yaml:"projects"`
+}
+
+// ReadProjectHierarchy reads a project hierarchy from a yaml file
+func ReadProjectHierarchy(path string) (ProjectHierarchy, error) {
+	hierarchy := ProjectHierarchy{}
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return hierarchy, errors.Wrapf(err, "can not read %s", path)
+	}
+	err = yaml.UnmarshalStrict(content, &hierarchy)
+	if err != nil {
+		return hierarchy, errors.Wrapf(err, "can not yaml decode %s", path)
+	}
+	return hierarchy, nil
+}
+
+// ProjectChangeAction is what a ProjectChange does on the server
+type ProjectChangeAction string
+
+const (
+	ProjectCreate ProjectChangeAction = "create"
+	ProjectUpdate ProjectChangeAction = "update"
+	ProjectDelete ProjectChangeAction = "delete"
+)
+
+// ProjectChange is a single step of a project sync plan
+type ProjectChange struct {
+	Action ProjectChangeAction
+	Path   string
+	// Spec is the desired state, unset for deletes
+	Spec ProjectSpec
+	// Node is the project on the server, unset for creates
+	Node *ProjectNode
+	// Details describes what differs between Spec and Node
+	Details []string
+	// Skipped tells why the change is not applied, e.g. a pruned project that is not empty
+	Skipped string
+}
+
+func (change ProjectChange) String() string {
+	if change.Skipped != "" {
+		return fmt.Sprintf("! %s %s: %s", change.Action, change.Path, change.Skipped)
+	}
+	symbol := map[ProjectChangeAction]string{ProjectCreate: "+", ProjectUpdate: "~", ProjectDelete: "-"}[change.Action]
+	line := fmt.Sprintf("%s %s %s", symbol, change.Action, change.Path)
+	if len(change.Details) > 0 {
+		line += " (" + strings.Join(change.Details, ", ") + ")"
+	}
+	return line
+}
+
+// PlanProjectSync compares the desired project hierarchy with the projects of the current site
+// and returns the changes needed to make the site match it.
+// Creates come parents first, deletes children first.
+// With prune, projects on the server that are not in the hierarchy are deleted (except the Default project),
+// projects that (would still) contain workbooks, datasources, flows or kept projects are planned as skipped.
+func (tabl *TabGo) PlanProjectSync(hierarchy ProjectHierarchy, prune bool) ([]ProjectChange, error) {
+	roots, err := tabl.cachedProjectTree()
+	if err != nil {
+		return nil, errors.Wrapf(err, "can not get projects")
+	}
+
+	owners := make(map[string]ResourceIdType)
+	var resolveOwners func(specs []ProjectSpec) error
+	resolveOwners = func(specs []ProjectSpec) error {
+		for _, spec := range specs {
+			if _, found := owners[spec.Owner]; spec.Owner != "" && !found {
+				user, err := tabl.GetUserByName(spec.Owner)
+				if err != nil {
+					return errors.Wrapf(err, "can not find owner of project '%s'", spec.Name)
+				}
+				owners[spec.Owner] = user.Id
+			}
+			if err := resolveOwners(spec.Projects); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := resolveOwners(hierarchy.Projects); err != nil {
+		return nil, err
+	}
+
+	var contents map[ResourceIdType]*ProjectContent
+	if prune {
+		if contents, err = tabl.projectContents(); err != nil {
+			return nil, err
+		}
+	}
+
+	return planProjectSync(roots, hierarchy, owners, contents), nil
+}
+
+// projectContents returns the workbooks, datasources and flows of the current site by the id of their project
+func (tabl *TabGo) projectContents() (map[ResourceIdType]*ProjectContent, error) {
+	contents := make(map[ResourceIdType]*ProjectContent)
+	content := func(projectID ResourceIdType) *ProjectContent {
+		if contents[projectID] == nil {
+			contents[projectID] = &ProjectContent{}
+		}
+		return contents[projectID]
+	}
+
+	workbooks, err := tabl.ListWorkbooks("")
+	if err != nil {
+		return contents, err
+	}
+	for _, workbook := range workbooks {
+		content(workbook.Project.Id).Workbooks = append(content(workbook.Project.Id).Workbooks, workbook)
+	}
+	datasources, err := tabl.ListDatasources("")
+	if err != nil {
+		return contents, err
+	}
+	for _, datasource := range datasources {
+		content(datasource.Project.Id).Datasources = append(content(datasource.Project.Id).Datasources, datasource)
+	}
+	flows, err := tabl.ListFlows("")
+	if err != nil {
+		return contents, err
+	}
+	for _, flow := range flows {
+		content(flow.Project.Id).Flows = append(content(flow.Project.Id).Flows, flow)
+	}
+	return contents, nil
+}
+
+// planProjectSync plans the changes of a project sync, contents is nil without prune
+func planProjectSync(roots []*ProjectNode, hierarchy ProjectHierarchy, owners map[string]ResourceIdType, contents map[ResourceIdType]*ProjectContent) []ProjectChange {
+	changes := []ProjectChange{}
+	known := make(map[*ProjectNode]bool)
+	// creates holds the index in changes of the create of every path, so overlapping specs create a project once
+	creates := make(map[string]int)
+
+	var plan func(siblings []*ProjectNode, parentPath string, specs []ProjectSpec)
+	plan = func(siblings []*ProjectNode, parentPath string, specs []ProjectSpec) {
+		for _, spec := range specs {
+			names := SplitProjectPath(spec.Name)
+			path := parentPath
+			levelSiblings := siblings
+			var node *ProjectNode
+			for i, name := range names {
+				path = appendProjectPath(path, name)
+				node = findProjectNode(levelSiblings, name)
+				if node == nil {
+					levelSpec := ProjectSpec{Name: name}
+					if i == len(names)-1 {
+						levelSpec = spec
+						levelSpec.Name = name
+					}
+					if index, found := creates[path]; found {
+						changes[index].Spec = mergeProjectSpec(changes[index].Spec, levelSpec)
+						changes[index].Details = specDetails(changes[index].Spec)
+					} else {
+						creates[path] = len(changes)
+						changes = append(changes, ProjectChange{Action: ProjectCreate, Path: path, Spec: levelSpec, Details: specDetails(levelSpec)})
+					}
+					levelSiblings = nil
+					continue
+				}
+				known[node] = true
+				levelSiblings = node.Children
+			}
+
+			if node != nil {
+				if details := projectDrift(node, spec, owners); len(details) > 0 {
+					changes = append(changes, ProjectChange{Action: ProjectUpdate, Path: path, Spec: spec, Node: node, Details: details})
+				}
+			}
+			plan(levelSiblings, path, spec.Projects)
+		}
+	}
+	plan(roots, "", hierarchy.Projects)
+
+	if contents != nil {
+		deletes := []ProjectChange{}
+		for _, root := range roots {
+			root.Walk(func(node *ProjectNode, depth int) {
+				if known[node] || (node.Parent == nil && node.Project.Name == defaultProjectName) {
+					return
+				}
+				deletes = append(deletes, ProjectChange{Action: ProjectDelete, Path: node.Path(), Node: node})
+			})
+		}
+		// delete the deepest projects first, so parents are empty by the time they are deleted
+		sort.SliceStable(deletes, func(i, j int) bool {
+			return deletes[i].Node.Depth() > deletes[j].Node.Depth()
+		})
+
+		// a project is only deleted when it has no content and all of its nested projects are deleted
+		deleted := make(map[*ProjectNode]bool)
+		for i, change := range deletes {
+			if content := contents[change.Node.Project.Id]; content != nil && !content.IsEmpty() {
+				deletes[i].Skipped = fmt.Sprintf("not empty (%s)", content)
+				continue
+			}
+			for _, child := range change.Node.Children {
+				if !deleted[child] {
+					deletes[i].Skipped = fmt.Sprintf("nested project '%s' is kept", child.Project.Name)
+					break
+				}
+			}
+			if deletes[i].Skipped == "" {
+				deleted[change.Node] = true
+			}
+		}
+		changes = append(changes, deletes...)
+	}
+
+	return changes
+}
+
+func findProjectNode(nodes []*ProjectNode, name string) *ProjectNode {
+	for _, node := range nodes {
+		if node.Project.Name == name {
+			return node
+		}
+	}
+	return nil
+}
+
+// mergeProjectSpec returns spec with the empty details filled in from other, e.g. of an overlapping spec of the same project
+func mergeProjectSpec(spec, other ProjectSpec) ProjectSpec {
+	if spec.Description == "" {
+		spec.Description = other.Description
+	}
+	if spec.ContentPermissions == "" {
+		spec.ContentPermissions = other.ContentPermissions
+	}
+	if spec.Owner == "" {
+		spec.Owner = other.Owner
+	}
+	return spec
+}
+
+// appendProjectPath returns the path of the project name within the project at parentPath
+func appendProjectPath(parentPath, name string) string {
+	if parentPath == "" {
+		return EscapeProjectName(name)
+	}
+	return parentPath + "/" + EscapeProjectName(name)
+}
+
+func specDetails(spec ProjectSpec) []string {
+	details := []string{}
+	if spec.Description != "" {
+		details = append(details, fmt.Sprintf("description: '%s'", spec.Description))
+	}
+	if spec.ContentPermissions != "" {
+		details = append(details, fmt.Sprintf("contentPermissions: %s", spec.ContentPermissions))
+	}
+	if spec.Owner != "" {
+		details = append(details, fmt.Sprintf("owner: %s", spec.Owner))
+	}
+	return details
+}
+
+func projectDrift(node *ProjectNode, spec ProjectSpec, owners map[string]ResourceIdType) []string {
+	details := []string{}
+	if spec.Description != "" && spec.Description != node.Project.Description {
+		details = append(details, fmt.Sprintf("description: '%s' -> '%s'", node.Project.Description, spec.Description))
+	}
+	if spec.ContentPermissions != "" && spec.ContentPermissions != node.Project.ContentPermissions {
+		details = append(details, fmt.Sprintf("contentPermissions: %s -> %s", node.Project.ContentPermissions, spec.ContentPermissions))
+	}
+	if spec.Owner != "" && owners[spec.Owner] != node.Project.Owner.Id {
+		details = append(details, fmt.Sprintf("owner: %s -> %s", node.Project.Owner.Id, spec.Owner))
+	}
+	return details
+}
+
+// ApplyProjectSync executes the changes of a project sync plan that are not skipped, in order.
+// Projects that contained content when the plan was made are skipped by the plan and not deleted.
+func (tabl *TabGo) ApplyProjectSync(changes []ProjectChange) error {
+	owners := make(map[string]string)
+
+	ownerID := func(name string) (string, error) {
+		if id, found := owners[name]; found {
+			return id, nil
+		}
+		user, err := tabl.GetUserByName(name)
+		if err != nil {
+			return "", err
+		}
+		owners[name] = string(user.Id)
+		return owners[name], nil
+	}
+
+	for _, change := range changes {
+		if change.Skipped != "" {
+			continue
+		}
+		switch change.Action {
+		case ProjectCreate:
+			// parents are created by earlier changes, GetProjectID finds them in the cached project hierarchy
+			parentID := ""
+			if names := SplitProjectPath(change.Path); len(names) > 1 {
+				var err error
+				if parentID, err = tabl.GetProjectID(JoinProjectPath(names[:len(names)-1]...)); err != nil {
+					return errors.Wrapf(err, "can not find parent of project '%s'", change.Path)
+				}
+			}
+			project, err := tabl.CreateProjectWithDetails(ProjectType{
+				Name:               change.Spec.Name,
+				Description:        change.Spec.Description,
+				ContentPermissions: change.Spec.ContentPermissions,
+				ParentProjectId:    ResourceIdType(parentID),
+			})
+			if err != nil {
+				return errors.Wrapf(err, "can not create project '%s'", change.Path)
+			}
+			if change.Spec.Owner != "" {
+				id, err := ownerID(change.Spec.Owner)
+				if err != nil {
+					return errors.Wrapf(err, "can not find owner of project '%s'", change.Path)
+				}
+				if _, err = tabl.UpdateProjectOwner(string(project.Id), id); err != nil {
+					return err
+				}
+			}
+
+		case ProjectUpdate:
+			projectID := string(change.Node.Project.Id)
+			var description *string
+			contentPermissions := ContentPermissions("")
+			if change.Spec.Description != "" && change.Spec.Description != change.Node.Project.Description {
+				description = &change.Spec.Description
+			}
+			if change.Spec.ContentPermissions != change.Node.Project.ContentPermissions {
+				contentPermissions = change.Spec.ContentPermissions
+			}
+			if description != nil || contentPermissions != "" {
+				if _, err := tabl.UpdateProject(projectID, "", description, contentPermissions); err != nil {
+					return err
+				}
+			}
+			if change.Spec.Owner != "" {
+				id, err := ownerID(change.Spec.Owner)
+				if err != nil {
+					return errors.Wrapf(err, "can not find owner of project '%s'", change.Path)
+				}
+				if ResourceIdType(id) != change.Node.Project.Owner.Id {
+					if _, err = tabl.UpdateProjectOwner(projectID, id); err != nil {
+						return err
+					}
+				}
+			}
+
+		case ProjectDelete:
+			// the plan already verified that the project is empty, no need to list the content of the site again
+			if err := tabl.DeleteProject(string(change.Node.Project.Id), true); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
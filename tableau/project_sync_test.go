@@ -0,0 +1,118 @@
+package tableau
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPlanProjectSync(t *testing.T) {
+	projects := []ProjectType{
+		{Id: "default", Name: "Default"},
+		{Id: "finance", Name: "Finance", Description: "financial reporting", ContentPermissions: ContentPermissionsLockedToProject, Owner: UserType{Id: "jdoe"}},
+		{Id: "reports", Name: "Reports", ParentProjectId: "finance"},
+		{Id: "old", Name: "Old"},
+		{Id: "old-sub", Name: "Sub", ParentProjectId: "old"},
+		{Id: "full", Name: "Full"},
+		{Id: "kept-parent", Name: "Parent"},
+		{Id: "kept-child", Name: "Child", ParentProjectId: "kept-parent"},
+	}
+	owners := map[string]ResourceIdType{"jdoe": "jdoe", "asmith": "asmith"}
+
+	tests := []struct {
+		name      string
+		hierarchy ProjectHierarchy
+		contents  map[ResourceIdType]*ProjectContent
+		changes   []string
+	}{
+		{
+			name: "up to date",
+			hierarchy: ProjectHierarchy{Projects: []ProjectSpec{
+				{Name: "Finance", Description: "financial reporting", Projects: []ProjectSpec{{Name: "Reports"}}},
+			}},
+			changes: []string{},
+		},
+		{
+			name: "empty values are not managed",
+			hierarchy: ProjectHierarchy{Projects: []ProjectSpec{
+				{Name: "Finance"},
+			}},
+			changes: []string{},
+		},
+		{
+			name: "drift",
+			hierarchy: ProjectHierarchy{Projects: []ProjectSpec{
+				{Name: "Finance", Description: "finance", ContentPermissions: ContentPermissionsManagedByOwner, Owner: "asmith"},
+			}},
+			changes: []string{
+				"~ update Finance (description: 'financial reporting' -> 'finance', contentPermissions: LockedToProject -> ManagedByOwner, owner: jdoe -> asmith)",
+			},
+		},
+		{
+			name: "create nested path, parents first",
+			hierarchy: ProjectHierarchy{Projects: []ProjectSpec{
+				{Name: "Finance/Budget/2020", Description: "budget"},
+				{Name: "HR", Projects: []ProjectSpec{{Name: `Pay\/Roll`}}},
+			}},
+			changes: []string{
+				"+ create Finance/Budget",
+				"+ create Finance/Budget/2020 (description: 'budget')",
+				"+ create HR",
+				`+ create HR/Pay\/Roll`,
+			},
+		},
+		{
+			name: "overlapping specs create once",
+			hierarchy: ProjectHierarchy{Projects: []ProjectSpec{
+				{Name: "HR/Payroll"},
+				{Name: "HR", Description: "human resources", Projects: []ProjectSpec{{Name: "Payroll", Owner: "jdoe"}}},
+			}},
+			changes: []string{
+				"+ create HR (description: 'human resources')",
+				"+ create HR/Payroll (owner: jdoe)",
+			},
+		},
+		{
+			name: "prune deletes children first and skips projects with content",
+			hierarchy: ProjectHierarchy{Projects: []ProjectSpec{
+				{Name: "Finance/Reports"},
+				{Name: "Parent/Child"},
+			}},
+			contents: map[ResourceIdType]*ProjectContent{
+				"full": {Workbooks: []WorkbookType{{Name: "Revenue"}}},
+			},
+			changes: []string{
+				"- delete Old/Sub",
+				"! delete Full: not empty (0 projects, 1 workbooks, 0 datasources, 0 flows)",
+				"- delete Old",
+			},
+		},
+		{
+			name: "prune keeps parents of kept projects",
+			hierarchy: ProjectHierarchy{Projects: []ProjectSpec{
+				{Name: "Finance/Reports"},
+			}},
+			contents: map[ResourceIdType]*ProjectContent{
+				"kept-child": {Datasources: []DataSourceType{{Name: "Sales"}}},
+			},
+			changes: []string{
+				"- delete Old/Sub",
+				"! delete Parent/Child: not empty (0 projects, 0 workbooks, 1 datasources, 0 flows)",
+				"- delete Full",
+				"- delete Old",
+				"! delete Parent: nested project 'Child' is kept",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			changes := planProjectSync(ProjectTree(projects), test.hierarchy, owners, test.contents)
+			lines := []string{}
+			for _, change := range changes {
+				lines = append(lines, change.String())
+			}
+			if !reflect.DeepEqual(lines, test.changes) {
+				t.Errorf("plan:\n%q\nexpecting:\n%q", lines, test.changes)
+			}
+		})
+	}
+}
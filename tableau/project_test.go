@@ -0,0 +1,142 @@
+package tableau
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitProjectPath(t *testing.T) {
+	tests := []struct {
+		path  string
+		names []string
+	}{
+		{"", []string{}},
+		{"Finance", []string{"Finance"}},
+		{"Finance/Reports/Monthly", []string{"Finance", "Reports", "Monthly"}},
+		{"/Finance/Reports/", []string{"Finance", "Reports"}},
+		{"Finance//Reports", []string{"Finance", "Reports"}},
+		{`Sales\/Marketing/Reports`, []string{"Sales/Marketing", "Reports"}},
+		{`C:\\Data/Reports`, []string{`C:\Data`, "Reports"}},
+		{`Trailing\`, []string{"Trailing"}},
+	}
+	for _, test := range tests {
+		if names := SplitProjectPath(test.path); !reflect.DeepEqual(names, test.names) {
+			t.Errorf("SplitProjectPath(%q) = %q, expecting %q", test.path, names, test.names)
+		}
+	}
+}
+
+func TestJoinProjectPath(t *testing.T) {
+	tests := []struct {
+		names []string
+		path  string
+	}{
+		{nil, ""},
+		{[]string{"Finance"}, "Finance"},
+		{[]string{"Finance", "Reports"}, "Finance/Reports"},
+		{[]string{"Sales/Marketing", "Reports"}, `Sales\/Marketing/Reports`},
+		{[]string{`C:\Data`}, `C:\\Data`},
+	}
+	for _, test := range tests {
+		path := JoinProjectPath(test.names...)
+		if path != test.path {
+			t.Errorf("JoinProjectPath(%q) = %q, expecting %q", test.names, path, test.path)
+		}
+		if names := SplitProjectPath(path); len(test.names) > 0 && !reflect.DeepEqual(names, test.names) {
+			t.Errorf("SplitProjectPath(JoinProjectPath(%q)) = %q", test.names, names)
+		}
+	}
+}
+
+func TestProjectNodePath(t *testing.T) {
+	roots := ProjectTree([]ProjectType{
+		{Id: "1", Name: "Finance"},
+		{Id: "2", Name: "Sales/Marketing", ParentProjectId: "1"},
+		{Id: "3", Name: "Reports", ParentProjectId: "2"},
+	})
+	if len(roots) != 1 {
+		t.Fatalf("expecting 1 top level project, got %d", len(roots))
+	}
+	node := roots[0].Child("Sales/Marketing").Child("Reports")
+	if node == nil {
+		t.Fatalf("project Finance/Sales\\/Marketing/Reports not found")
+	}
+	if path := node.Path(); path != `Finance/Sales\/Marketing/Reports` {
+		t.Errorf("Path() = %q", path)
+	}
+	if depth := node.Depth(); depth != 2 {
+		t.Errorf("Depth() = %d, expecting 2", depth)
+	}
+}
+
+func TestProjectTree(t *testing.T) {
+	roots := ProjectTree([]ProjectType{
+		{Id: "4", Name: "reports", ParentProjectId: "1"},
+		{Id: "1", Name: "Finance"},
+		{Id: "2", Name: "Archive", ParentProjectId: "1"},
+		{Id: "3", Name: "HR"},
+		{Id: "5", Name: "Orphan", ParentProjectId: "gone"},
+		{Id: "6", Name: "Budget", ParentProjectId: "4"},
+	})
+	lines := []string{}
+	for _, root := range roots {
+		root.Walk(func(node *ProjectNode, depth int) {
+			lines = append(lines, strings.Repeat("  ", depth)+node.Project.Name)
+		})
+	}
+	expected := []string{"Finance", "  Archive", "  reports", "    Budget", "HR", "Orphan"}
+	if !reflect.DeepEqual(lines, expected) {
+		t.Errorf("tree:\n%q\nexpecting:\n%q", lines, expected)
+	}
+}
+
+func TestProjectContent(t *testing.T) {
+	tests := []struct {
+		content ProjectContent
+		empty   bool
+		summary string
+	}{
+		{ProjectContent{}, true, "0 projects, 0 workbooks, 0 datasources, 0 flows"},
+		{ProjectContent{Projects: []ProjectType{{}}}, false, "1 projects, 0 workbooks, 0 datasources, 0 flows"},
+		{ProjectContent{Workbooks: []WorkbookType{{}, {}}, Flows: []FlowType{{}}}, false, "0 projects, 2 workbooks, 0 datasources, 1 flows"},
+	}
+	for _, test := range tests {
+		if empty := test.content.IsEmpty(); empty != test.empty {
+			t.Errorf("%s: IsEmpty() = %t", test.summary, empty)
+		}
+		if summary := test.content.String(); summary != test.summary {
+			t.Errorf("String() = %q, expecting %q", summary, test.summary)
+		}
+	}
+}
+
+func TestUpdateProjectPayload(t *testing.T) {
+	description, empty := "Sales & Marketing", ""
+
+	tests := []struct {
+		name               string
+		projectName        string
+		description        *string
+		contentPermissions ContentPermissions
+		request            string
+	}{
+		{"nothing", "", nil, "", `PUT /projects/p1 <tsRequest><project></project></tsRequest>`},
+		{"escaped name and description", `"Q1" <draft>`, &description, "",
+			`PUT /projects/p1 <tsRequest><project name="&#34;Q1&#34; &lt;draft&gt;" description="Sales &amp; Marketing"></project></tsRequest>`},
+		{"cleared description", "", &empty, ContentPermissionsLockedToProject,
+			`PUT /projects/p1 <tsRequest><project description="" contentPermissions="LockedToProject"></project></tsRequest>`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tabl, requests, cleanup := newRecordingServer(t, 200)
+			defer cleanup()
+			if _, err := tabl.UpdateProject("p1", test.projectName, test.description, test.contentPermissions); err != nil {
+				t.Fatal(err)
+			}
+			if len(*requests) != 1 || (*requests)[0] != test.request {
+				t.Errorf("requests = %q, expecting %q", *requests, test.request)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package tableau
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// RefreshDatasource starts an extract refresh of a published datasource and returns the job running it.
+// A full refresh rebuilds the extract, an incremental refresh runs the (first) incremental extract refresh task
+// of the datasource outside of its schedule, so the datasource needs such a task (cfr AddDatasourceToSchedule).
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_datasources.htm#update_data_source_now
+func (tabl *TabGo) RefreshDatasource(datasourceID string, incremental bool) (JobType, error) {
+	if incremental {
+		return tabl.runIncrementalRefresh(KindDatasource, datasourceID)
+	}
+	tsResponse, err := tabl.doTsRequest("POST", fmt.Sprintf("%s/datasources/%s/refresh", tabl.SiteURL(), datasourceID), "<tsRequest />")
+	if err != nil {
+		return tsResponse.Job, errors.Wrapf(err, "can not refresh datasource '%s'", datasourceID)
+	}
+	return tsResponse.Job, nil
+}
+
+// RefreshWorkbook starts an extract refresh of a workbook and returns the job running it,
+// an incremental refresh needs an incremental extract refresh task of the workbook, cfr RefreshDatasource
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_workbooksviews.htm#update_workbook_now
+func (tabl *TabGo) RefreshWorkbook(workbookID string, incremental bool) (JobType, error) {
+	if incremental {
+		return tabl.runIncrementalRefresh(KindWorkbook, workbookID)
+	}
+	tsResponse, err := tabl.doTsRequest("POST", fmt.Sprintf("%s/workbooks/%s/refresh", tabl.SiteURL(), workbookID), "<tsRequest />")
+	if err != nil {
+		return tsResponse.Job, errors.Wrapf(err, "can not refresh workbook '%s'", workbookID)
+	}
+	return tsResponse.Job, nil
+}
+
+// runIncrementalRefresh runs the first incremental extract refresh task of a datasource or workbook now
+func (tabl *TabGo) runIncrementalRefresh(kind ContentKind, id string) (JobType, error) {
+	tasks, err := tabl.ListExtractRefreshTasks()
+	if err != nil {
+		return JobType{}, err
+	}
+	for _, task := range tasks {
+		if taskContentKey(task) == string(kind)+"/"+id && IsIncrementalRefresh(task) {
+			return tabl.RunExtractRefreshTask(task.Id)
+		}
+	}
+	return JobType{}, fmt.Errorf("%s '%s' has no incremental extract refresh task, add it to a schedule with an incremental refresh first", kind.element(), id)
+}
+
+// IsIncrementalRefresh tells whether an extract refresh task refreshes incrementally,
+// tableau reports this either with the incremental attribute or with the type of the task
+func IsIncrementalRefresh(task TaskExtractRefreshType) bool {
+	return task.Incremental || strings.Contains(strings.ToLower(task.Type), "increment")
+}
+
+// ListExtractRefreshTasks returns the extract refresh tasks of the current site
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_jobstasksschedules.htm#list_extract_refresh_tasks1
+func (tabl *TabGo) ListExtractRefreshTasks() ([]TaskExtractRefreshType, error) {
+	tasks := []TaskExtractRefreshType{}
+	tsResponse, err := tabl.doTsRequest("GET", fmt.Sprintf("%s/tasks/extractRefreshes", tabl.SiteURL()), "")
+	if err != nil {
+		return tasks, errors.Wrapf(err, "can not list extract refresh tasks")
+	}
+	for _, task := range tsResponse.Tasks.Task {
+		tasks = append(tasks, task.ExtractRefresh)
+	}
+	return tasks, nil
+}
+
+// AddDatasourceToSchedule creates a task that refreshes the extract of a published datasource on a schedule,
+// fully or incrementally
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_jobstasksschedules.htm#add_data_source_to_schedule
+func (tabl *TabGo) AddDatasourceToSchedule(scheduleID, datasourceID string, incremental bool) (TaskExtractRefreshType, error) {
+	tsResponse, err := tabl.doTsRequest("PUT", fmt.Sprintf("%s/schedules/%s/datasources", tabl.SiteURL(), scheduleID),
+		fmt.Sprintf(`<tsRequest><task><extractRefresh incremental="%t"><datasource id="%s" /></extractRefresh></task></tsRequest>`, incremental, datasourceID))
+	if err != nil {
+		return tsResponse.Task.ExtractRefresh, errors.Wrapf(err, "can not add datasource '%s' to schedule '%s'", datasourceID, scheduleID)
+	}
+	return tsResponse.Task.ExtractRefresh, nil
+}
+
+// AddWorkbookToSchedule creates a task that refreshes the extracts of a workbook on a schedule, fully or incrementally
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_jobstasksschedules.htm#add_workbook_to_schedule
+func (tabl *TabGo) AddWorkbookToSchedule(scheduleID, workbookID string, incremental bool) (TaskExtractRefreshType, error) {
+	tsResponse, err := tabl.doTsRequest("PUT", fmt.Sprintf("%s/schedules/%s/workbooks", tabl.SiteURL(), scheduleID),
+		fmt.Sprintf(`<tsRequest><task><extractRefresh incremental="%t"><workbook id="%s" /></extractRefresh></task></tsRequest>`, incremental, workbookID))
+	if err != nil {
+		return tsResponse.Task.ExtractRefresh, errors.Wrapf(err, "can not add workbook '%s' to schedule '%s'", workbookID, scheduleID)
+	}
+	return tsResponse.Task.ExtractRefresh, nil
+}
+
+// EnsureExtractRefreshTask makes sure a datasource or workbook is refreshed on the named schedule, fully or incrementally.
+// A task of the content on the schedule with the other refresh type is replaced.
+func (tabl *TabGo) EnsureExtractRefreshTask(kind ContentKind, id, scheduleName string, incremental bool) error {
+	schedule, err := tabl.GetScheduleByName(scheduleName)
+	if err != nil {
+		return err
+	}
+	tasks, err := tabl.ListExtractRefreshTasks()
+	if err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		if task.Schedule.Id != schedule.Id || taskContentKey(task) != string(kind)+"/"+id {
+			continue
+		}
+		if IsIncrementalRefresh(task) == incremental {
+			return nil
+		}
+		if err = tabl.DeleteExtractRefreshTask(task.Id); err != nil {
+			return err
+		}
+	}
+	if kind == KindDatasource {
+		_, err = tabl.AddDatasourceToSchedule(string(schedule.Id), id, incremental)
+	} else {
+		_, err = tabl.AddWorkbookToSchedule(string(schedule.Id), id, incremental)
+	}
+	return err
+}
+
+// DeleteExtractRefreshTask removes an extract refresh task from its schedule
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_jobstasksschedules.htm#delete_extract_refresh_task
+func (tabl *TabGo) DeleteExtractRefreshTask(taskID string) error {
+	_, err := tabl.doRequest("DELETE", fmt.Sprintf("%s/tasks/extractRefreshes/%s", tabl.SiteURL(), taskID), "")
+	if err != nil {
+		return errors.Wrapf(err, "can not delete extract refresh task '%s'", taskID)
+	}
+	return nil
+}
+
+// RunExtractRefreshTask runs an extract refresh task now, outside of its schedule, and returns the job running it
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_jobstasksschedules.htm#run_extract_refresh_task
+func (tabl *TabGo) RunExtractRefreshTask(taskID string) (JobType, error) {
+	tsResponse, err := tabl.doTsRequest("POST", fmt.Sprintf("%s/tasks/extractRefreshes/%s/runNow", tabl.SiteURL(), taskID), "<tsRequest />")
+	if err != nil {
+		return tsResponse.Job, errors.Wrapf(err, "can not run extract refresh task '%s'", taskID)
+	}
+	return tsResponse.Job, nil
+}
+
+// ExtractWorkbookData creates extracts for all embedded datasources of a workbook, optionally encrypted
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_workbooksviews.htm#create_extracts_for_workbook
+func (tabl *TabGo) ExtractWorkbookData(workbookID string, encrypt bool) error {
+	_, err := tabl.doRequest("POST", fmt.Sprintf("%s/workbooks/%s/createExtract?encrypt=%t", tabl.SiteURL(), workbookID, encrypt),
+		`<tsRequest><datasources includeAll="true" /></tsRequest>`)
+	if err != nil {
+		return errors.Wrapf(err, "can not create extracts for workbook '%s'", workbookID)
+	}
+	return nil
+}
+
+// DeleteWorkbookExtracts deletes the extracts of all embedded datasources of a workbook
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_workbooksviews.htm#delete_extracts_from_workbook
+func (tabl *TabGo) DeleteWorkbookExtracts(workbookID string) error {
+	_, err := tabl.doRequest("POST", fmt.Sprintf("%s/workbooks/%s/deleteExtract", tabl.SiteURL(), workbookID), "")
+	if err != nil {
+		return errors.Wrapf(err, "can not delete extracts of workbook '%s'", workbookID)
+	}
+	return nil
+}
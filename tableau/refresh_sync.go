@@ -0,0 +1,261 @@
+package tableau
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// RefreshScheduleSpec is a schedule and the datasources and workbooks (by content path, cfr FindWorkbook)
+// whose extracts it refreshes on the current site, fully or (with Incremental) incrementally
+type RefreshScheduleSpec struct {
+	ScheduleSpec `yaml:",inline"`
+	Incremental  bool     `yaml:"incremental,omitempty"`
+	Datasources  []string `yaml:"datasources,omitempty"`
+	Workbooks    []string `yaml:"workbooks,omitempty"`
+}
+
+// RefreshManifest is the desired extract refresh schedules of a site.
+// The refresh tasks of a listed schedule that are not in the manifest are removed,
+// schedules that are not listed are left alone.
+// Example yaml:
+//
+//	schedules:
+//	  - name: Nightly
+//	    frequency: Daily
+//	    start: "02:00"
+//	    datasources: [Finance/Sales]
+//	    workbooks: [Finance/Reports/Revenue]
+//	  - name: Hourly facts
+//	    frequency: Hourly
+//	    start: "06:00"
+//	    end: "20:00"
+//	    hours: 1
+//	    incremental: true
+//	    datasources: [Finance/Facts]
+type RefreshManifest struct {
+	Schedules []RefreshScheduleSpec `yaml:"schedules"`
+}
+
+// ReadRefreshManifest reads a refresh manifest from a yaml file
+func ReadRefreshManifest(path string) (RefreshManifest, error) {
+	manifest := RefreshManifest{}
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return manifest, errors.Wrapf(err, "can not read %s", path)
+	}
+	err = yaml.UnmarshalStrict(content, &manifest)
+	if err != nil {
+		return manifest, errors.Wrapf(err, "can not yaml decode %s", path)
+	}
+	return manifest, nil
+}
+
+// RefreshChangeAction is what a RefreshChange does on the server
+type RefreshChangeAction string
+
+const (
+	RefreshScheduleCreate RefreshChangeAction = "create"
+	RefreshScheduleUpdate RefreshChangeAction = "update"
+	RefreshTaskAdd        RefreshChangeAction = "add"
+	RefreshTaskRemove     RefreshChangeAction = "remove"
+)
+
+// RefreshChange is a single step of a refresh sync plan
+type RefreshChange struct {
+	Action RefreshChangeAction
+	// Schedule is the desired schedule, ScheduleID is unset when the schedule is created by an earlier change
+	Schedule   ScheduleType
+	ScheduleID string
+	// Kind, ContentID and ContentPath identify the datasource or workbook of a task,
+	// ContentPath falls back to the id of content without a known path
+	Kind        ContentKind
+	ContentID   string
+	ContentPath string
+	TaskID      string
+	Incremental bool
+	Details     []string
+}
+
+func (change RefreshChange) String() string {
+	switch change.Action {
+	case RefreshTaskAdd, RefreshTaskRemove:
+		symbol := map[RefreshChangeAction]string{RefreshTaskAdd: "+", RefreshTaskRemove: "-"}[change.Action]
+		refreshType := "full"
+		if change.Incremental {
+			refreshType = "incremental"
+		}
+		return fmt.Sprintf("%s %s: %s refresh %s %s", symbol, change.Schedule.Name, refreshType, change.Kind.element(), change.ContentPath)
+	}
+	symbol := map[RefreshChangeAction]string{RefreshScheduleCreate: "+", RefreshScheduleUpdate: "~"}[change.Action]
+	line := fmt.Sprintf("%s %s schedule %s", symbol, change.Action, change.Schedule.Name)
+	if len(change.Details) > 0 {
+		line += " (" + strings.Join(change.Details, ", ") + ")"
+	}
+	return line
+}
+
+// PlanRefreshSync compares the refresh manifest with the schedules of the server and the extract refresh tasks of the current site
+// and returns the changes needed to make them match it
+func (tabl *TabGo) PlanRefreshSync(manifest RefreshManifest) ([]RefreshChange, error) {
+	schedules, err := tabl.ListSchedules()
+	if err != nil {
+		return []RefreshChange{}, err
+	}
+	tasks, err := tabl.ListExtractRefreshTasks()
+	if err != nil {
+		return []RefreshChange{}, err
+	}
+
+	contentIDs := make(map[string]string)
+	for _, spec := range manifest.Schedules {
+		for _, path := range spec.Datasources {
+			datasource, err := tabl.FindDatasource(path)
+			if err != nil {
+				return []RefreshChange{}, err
+			}
+			contentIDs[string(KindDatasource)+"/"+path] = string(datasource.Id)
+		}
+		for _, path := range spec.Workbooks {
+			workbook, err := tabl.FindWorkbook(path)
+			if err != nil {
+				return []RefreshChange{}, err
+			}
+			contentIDs[string(KindWorkbook)+"/"+path] = string(workbook.Id)
+		}
+	}
+	// the paths of the content of removed tasks
+	contentPaths := make(map[string]string)
+	for _, kind := range []ContentKind{KindDatasource, KindWorkbook} {
+		contents, err := tabl.ListContent(kind, "")
+		if err != nil {
+			return []RefreshChange{}, err
+		}
+		for _, content := range contents {
+			contentPaths[string(kind)+"/"+string(content.Id)] = content.Path
+		}
+	}
+	return planRefreshSync(manifest, schedules, tasks, contentIDs, contentPaths)
+}
+
+// planRefreshSync plans the changes of a refresh sync,
+// contentIDs are the ids of the content of the manifest by kind and path, e.g. "datasources/Finance/Sales",
+// contentPaths the paths of the content of the site by kind and id (cfr taskContentKey)
+func planRefreshSync(manifest RefreshManifest, schedules []ScheduleType, tasks []TaskExtractRefreshType,
+	contentIDs map[string]string, contentPaths map[string]string) ([]RefreshChange, error) {
+	changes := []RefreshChange{}
+
+	for _, spec := range manifest.Schedules {
+		desired, err := spec.Schedule()
+		if err != nil {
+			return changes, err
+		}
+
+		scheduleID := ""
+		for _, schedule := range schedules {
+			if schedule.Name == desired.Name {
+				scheduleID = string(schedule.Id)
+				if details := scheduleDrift(desired, schedule); len(details) > 0 {
+					changes = append(changes, RefreshChange{Action: RefreshScheduleUpdate, Schedule: desired, ScheduleID: scheduleID, Details: details})
+				}
+			}
+		}
+		if scheduleID == "" {
+			changes = append(changes, RefreshChange{Action: RefreshScheduleCreate, Schedule: desired, Details: []string{describeFrequencyDetails(desired.FrequencyDetails)}})
+		}
+
+		current := make(map[string]TaskExtractRefreshType)
+		for _, task := range tasks {
+			if scheduleID != "" && string(task.Schedule.Id) == scheduleID {
+				current[taskContentKey(task)] = task
+			}
+		}
+
+		// removals come before the adds of the schedule, so a task can change its refresh type
+		firstTaskChange := len(changes)
+		wanted := make(map[string]bool)
+		plan := func(kind ContentKind, contentPath string) {
+			contentID := contentIDs[string(kind)+"/"+contentPath]
+			key := string(kind) + "/" + contentID
+			wanted[key] = true
+			if task, found := current[key]; found && IsIncrementalRefresh(task) == spec.Incremental {
+				return
+			}
+			changes = append(changes, RefreshChange{Action: RefreshTaskAdd, Schedule: desired, ScheduleID: scheduleID, Kind: kind, ContentID: contentID, ContentPath: contentPath, Incremental: spec.Incremental})
+		}
+		for _, path := range spec.Datasources {
+			plan(KindDatasource, path)
+		}
+		for _, path := range spec.Workbooks {
+			plan(KindWorkbook, path)
+		}
+
+		// tasks with the wrong refresh type are replaced, so they are removed too
+		removals := []RefreshChange{}
+		for key, task := range current {
+			if wanted[key] && IsIncrementalRefresh(task) == spec.Incremental {
+				continue
+			}
+			kind, contentID := KindWorkbook, string(task.Workbook.Id)
+			if task.Datasource.Id != "" {
+				kind, contentID = KindDatasource, string(task.Datasource.Id)
+			}
+			contentPath, found := contentPaths[key]
+			if !found {
+				contentPath = contentID
+			}
+			removals = append(removals, RefreshChange{Action: RefreshTaskRemove, Schedule: desired, ScheduleID: scheduleID, Kind: kind, ContentID: contentID, ContentPath: contentPath, TaskID: task.Id, Incremental: IsIncrementalRefresh(task)})
+		}
+		sort.Slice(removals, func(i, j int) bool { return removals[i].ContentPath < removals[j].ContentPath })
+		changes = append(changes[:firstTaskChange], append(removals, changes[firstTaskChange:]...)...)
+	}
+	return changes, nil
+}
+
+// taskContentKey identifies the datasource or workbook an extract refresh task refreshes
+func taskContentKey(task TaskExtractRefreshType) string {
+	if task.Datasource.Id != "" {
+		return string(KindDatasource) + "/" + string(task.Datasource.Id)
+	}
+	return string(KindWorkbook) + "/" + string(task.Workbook.Id)
+}
+
+// ApplyRefreshSync executes the changes of a refresh sync plan, in order
+func (tabl *TabGo) ApplyRefreshSync(changes []RefreshChange) error {
+	scheduleIDs := make(map[string]string)
+	for _, change := range changes {
+		scheduleID := change.ScheduleID
+		if scheduleID == "" {
+			scheduleID = scheduleIDs[change.Schedule.Name]
+		}
+
+		var err error
+		switch change.Action {
+		case RefreshScheduleCreate:
+			var schedule ScheduleType
+			schedule, err = tabl.CreateSchedule(change.Schedule)
+			scheduleIDs[change.Schedule.Name] = string(schedule.Id)
+		case RefreshScheduleUpdate:
+			// the type of a schedule can not be changed
+			update := change.Schedule
+			update.Type = ""
+			_, err = tabl.UpdateSchedule(scheduleID, update)
+		case RefreshTaskAdd:
+			if change.Kind == KindDatasource {
+				_, err = tabl.AddDatasourceToSchedule(scheduleID, change.ContentID, change.Incremental)
+			} else {
+				_, err = tabl.AddWorkbookToSchedule(scheduleID, change.ContentID, change.Incremental)
+			}
+		case RefreshTaskRemove:
+			err = tabl.DeleteExtractRefreshTask(change.TaskID)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "can not apply '%s'", change)
+		}
+	}
+	return nil
+}
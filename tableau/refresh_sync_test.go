@@ -0,0 +1,135 @@
+package tableau
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestScheduleSpecSchedule(t *testing.T) {
+	tests := []struct {
+		name string
+		spec ScheduleSpec
+		// details is the description of the frequency details, cfr describeFrequencyDetails
+		details string
+		// problem is part of the expected error, "" when the spec is valid
+		problem string
+	}{
+		{"daily", ScheduleSpec{Name: "Nightly", Frequency: FrequencyDaily, Start: "02:00"}, "02:00:00", ""},
+		{"hourly", ScheduleSpec{Name: "Hourly", Frequency: FrequencyHourly, Start: "06:00", End: "20:00:30", Hours: 1}, "06:00:00-20:00:30 (every 1h)", ""},
+		{"weekly", ScheduleSpec{Name: "Weekly", Frequency: FrequencyWeekly, Start: "02:00", WeekDays: []WeekDay{"Monday", "Friday"}},
+			"02:00:00 (Monday, Friday)", ""},
+		{"monthly", ScheduleSpec{Name: "Monthly", Frequency: FrequencyMonthly, Start: "02:00"}, "", "Monthly schedules are not supported"},
+		{"invalid start", ScheduleSpec{Name: "Nightly", Frequency: FrequencyDaily, Start: "2am"}, "", "invalid start of schedule 'Nightly'"},
+		{"invalid end", ScheduleSpec{Name: "Hourly", Frequency: FrequencyHourly, Start: "06:00", End: "25:00"}, "", "invalid end of schedule 'Hourly'"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			schedule, err := test.spec.Schedule()
+			switch {
+			case test.problem == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case test.problem != "" && err == nil:
+				t.Fatalf("expecting error '%s'", test.problem)
+			case test.problem != "" && !strings.Contains(err.Error(), test.problem):
+				t.Fatalf("error '%v' does not contain '%s'", err, test.problem)
+			case test.problem != "":
+				return
+			}
+			if schedule.Type != ScheduleTypeExtract {
+				t.Errorf("type = %s, expecting %s", schedule.Type, ScheduleTypeExtract)
+			}
+			if details := describeFrequencyDetails(schedule.FrequencyDetails); details != test.details {
+				t.Errorf("frequency details = %s, expecting %s", details, test.details)
+			}
+		})
+	}
+}
+
+func TestPlanRefreshSync(t *testing.T) {
+	nightly, err := ScheduleSpec{Name: "Nightly", Frequency: FrequencyDaily, Start: "02:00"}.Schedule()
+	if err != nil {
+		t.Fatal(err)
+	}
+	nightly.Id = "nightly"
+	schedules := []ScheduleType{nightly}
+	tasks := []TaskExtractRefreshType{
+		{Id: "t1", Schedule: ScheduleType{Id: "nightly"}, Datasource: DataSourceType{Id: "sales"}},
+		{Id: "t2", Schedule: ScheduleType{Id: "nightly"}, Workbook: WorkbookType{Id: "revenue"}, Type: "RefreshExtractIncrementalTask"},
+		{Id: "t3", Schedule: ScheduleType{Id: "nightly"}, Datasource: DataSourceType{Id: "gone"}},
+	}
+	contentIDs := map[string]string{
+		"datasources/Finance/Sales":  "sales",
+		"datasources/Finance/Facts":  "facts",
+		"workbooks/Finance/Revenue":  "revenue",
+		"workbooks/Finance/Forecast": "forecast",
+	}
+	contentPaths := map[string]string{
+		"datasources/sales": "Finance/Sales",
+		"datasources/facts": "Finance/Facts",
+		"workbooks/revenue": "Finance/Revenue",
+	}
+
+	tests := []struct {
+		name      string
+		schedules []RefreshScheduleSpec
+		changes   []string
+	}{
+		{
+			name: "removals name the content by path, or id when it has none",
+			schedules: []RefreshScheduleSpec{
+				{ScheduleSpec: ScheduleSpec{Name: "Nightly", Frequency: FrequencyDaily, Start: "02:00"}, Datasources: []string{"Finance/Facts"}},
+			},
+			changes: []string{
+				"- Nightly: incremental refresh workbook Finance/Revenue",
+				"- Nightly: full refresh datasource Finance/Sales",
+				"- Nightly: full refresh datasource gone",
+				"+ Nightly: full refresh datasource Finance/Facts",
+			},
+		},
+		{
+			name: "a changed refresh type replaces the task",
+			schedules: []RefreshScheduleSpec{
+				{ScheduleSpec: ScheduleSpec{Name: "Nightly", Frequency: FrequencyDaily, Start: "02:00"},
+					Datasources: []string{"Finance/Sales"}, Workbooks: []string{"Finance/Revenue"}},
+			},
+			changes: []string{
+				"- Nightly: incremental refresh workbook Finance/Revenue",
+				"- Nightly: full refresh datasource gone",
+				"+ Nightly: full refresh workbook Finance/Revenue",
+			},
+		},
+		{
+			name: "changed and new schedules",
+			schedules: []RefreshScheduleSpec{
+				{ScheduleSpec: ScheduleSpec{Name: "Nightly", Frequency: FrequencyDaily, Start: "03:00", Priority: 10}, Incremental: true,
+					Datasources: []string{"Finance/Sales"}, Workbooks: []string{"Finance/Revenue"}},
+				{ScheduleSpec: ScheduleSpec{Name: "Hourly", Frequency: FrequencyHourly, Start: "06:00", End: "20:00", Hours: 1},
+					Workbooks: []string{"Finance/Forecast"}},
+			},
+			changes: []string{
+				"~ update schedule Nightly (priority: 0 -> 10, frequencyDetails: 02:00:00 -> 03:00:00)",
+				"- Nightly: full refresh datasource Finance/Sales",
+				"- Nightly: full refresh datasource gone",
+				"+ Nightly: incremental refresh datasource Finance/Sales",
+				"+ create schedule Hourly (06:00:00-20:00:00 (every 1h))",
+				"+ Hourly: full refresh workbook Finance/Forecast",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			changes, err := planRefreshSync(RefreshManifest{Schedules: test.schedules}, schedules, tasks, contentIDs, contentPaths)
+			if err != nil {
+				t.Fatal(err)
+			}
+			lines := []string{}
+			for _, change := range changes {
+				lines = append(lines, change.String())
+			}
+			if !reflect.DeepEqual(lines, test.changes) {
+				t.Errorf("plan:\n%q\nexpecting:\n%q", lines, test.changes)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package tableau
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestIsIncrementalRefresh(t *testing.T) {
+	tests := []struct {
+		task        TaskExtractRefreshType
+		incremental bool
+	}{
+		{TaskExtractRefreshType{}, false},
+		{TaskExtractRefreshType{Type: "FullRefresh"}, false},
+		{TaskExtractRefreshType{Type: "RefreshExtractTask"}, false},
+		{TaskExtractRefreshType{Type: "IncrementalRefresh"}, true},
+		{TaskExtractRefreshType{Type: "incrementalRefresh"}, true},
+		{TaskExtractRefreshType{Incremental: true}, true},
+	}
+	for _, test := range tests {
+		if IsIncrementalRefresh(test.task) != test.incremental {
+			t.Errorf("IsIncrementalRefresh(type %q, incremental %t) = %t", test.task.Type, test.task.Incremental, !test.incremental)
+		}
+	}
+}
+
+// extractRefreshServer answers the schedules and extract refresh tasks of the tests and records the other requests
+func extractRefreshServer(t *testing.T, tasks string) (*TabGo, *[]string, func()) {
+	requests := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := ""
+		switch path := strings.TrimPrefix(r.URL.Path, "/api/3.6"); {
+		case r.Method == "GET" && path == "/schedules":
+			body = `<schedules><schedule id="s1" name="Nightly" /><schedule id="s2" name="Hourly" /></schedules>`
+		case r.Method == "GET" && path == "/sites/site/tasks/extractRefreshes":
+			body = `<tasks>` + tasks + `</tasks>`
+		default:
+			requests = append(requests, r.Method+" "+strings.TrimPrefix(path, "/sites/site"))
+		}
+		w.Write([]byte(`<tsResponse xmlns="http://tableau.com/api">` + body + `</tsResponse>`))
+	}))
+	return &TabGo{ServerURL: server.URL, ApiVersion: "3.6", CurrentSiteID: "site"}, &requests, server.Close
+}
+
+func TestEnsureExtractRefreshTask(t *testing.T) {
+	tasks := `<task><extractRefresh id="t1" type="FullRefresh"><schedule id="s1" /><datasource id="d1" /></extractRefresh></task>
+		<task><extractRefresh id="t2" type="IncrementalRefresh"><schedule id="s2" /><workbook id="w1" /></extractRefresh></task>`
+
+	tests := []struct {
+		name        string
+		kind        ContentKind
+		id          string
+		schedule    string
+		incremental bool
+		requests    []string
+	}{
+		{"already scheduled", KindDatasource, "d1", "Nightly", false, []string{}},
+		{"other refresh type is replaced", KindDatasource, "d1", "Nightly", true,
+			[]string{"DELETE /tasks/extractRefreshes/t1", "PUT /schedules/s1/datasources"}},
+		{"other schedule is kept", KindDatasource, "d1", "Hourly", false, []string{"PUT /schedules/s2/datasources"}},
+		{"workbook", KindWorkbook, "w2", "Hourly", true, []string{"PUT /schedules/s2/workbooks"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tabl, requests, cleanup := extractRefreshServer(t, tasks)
+			defer cleanup()
+			if err := tabl.EnsureExtractRefreshTask(test.kind, test.id, test.schedule, test.incremental); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(*requests, test.requests) {
+				t.Errorf("requests = %q, expecting %q", *requests, test.requests)
+			}
+		})
+	}
+
+	tabl, _, cleanup := extractRefreshServer(t, tasks)
+	defer cleanup()
+	if err := tabl.EnsureExtractRefreshTask(KindDatasource, "d1", "Weekly", false); err == nil || err.Error() != "no schedule 'Weekly' found" {
+		t.Errorf("err = %v, expecting an unknown schedule", err)
+	}
+}
+
+func TestRefreshIncremental(t *testing.T) {
+	tabl, requests, cleanup := extractRefreshServer(t,
+		`<task><extractRefresh id="t2" incremental="true"><schedule id="s2" /><workbook id="w1" /></extractRefresh></task>`)
+	defer cleanup()
+
+	if _, err := tabl.RefreshWorkbook("w1", true); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tabl.RefreshDatasource("d1", true); err == nil || !strings.Contains(err.Error(), "has no incremental extract refresh task") {
+		t.Errorf("err = %v, expecting a datasource without incremental refresh task", err)
+	}
+	if expected := []string{"POST /tasks/extractRefreshes/t2/runNow"}; !reflect.DeepEqual(*requests, expected) {
+		t.Errorf("requests = %q, expecting %q", *requests, expected)
+	}
+}
@@ -0,0 +1,91 @@
+package tableau
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// defaultPageSize is the number of items requested per page when walking a paged tableau list
+const defaultPageSize = 100
+
+// SiteURL returns the api url of the site we are currently signed in to
+func (tabl *TabGo) SiteURL() string {
+	return fmt.Sprintf("%s/sites/%s", tabl.ApiURL(), tabl.CurrentSiteID)
+}
+
+// doRequest sends an xml payload (may be empty) to the tableau rest api and returns the response body.
+// An error is returned when tableau does not answer with a success-range (2xx) status.
+func (tabl *TabGo) doRequest(method, uri, payload string) ([]byte, error) {
+	req, err := http.NewRequest(method, uri, strings.NewReader(payload))
+	if err != nil {
+		return nil, errors.Wrapf(err, "can not create %s request for '%s'", method, uri)
+	}
+	req.Header.Set("Content-Type", "text/xml")
+	req.Header.Set("X-tableau-auth", tabl.CurrentToken)
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "can not client.Do(request) %s '%s'", method, uri)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "can not read response body")
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return body, fmt.Errorf("%s '%s' failed with status %d: %s", method, uri, resp.StatusCode, string(body))
+	}
+	return body, nil
+}
+
+// doTsRequest is doRequest, but unmarshals the response body into a TsResponse
+func (tabl *TabGo) doTsRequest(method, uri, payload string) (TsResponse, error) {
+	var tsResponse TsResponse
+	body, err := tabl.doRequest(method, uri, payload)
+	if err != nil {
+		return tsResponse, err
+	}
+	if len(body) == 0 {
+		return tsResponse, nil
+	}
+	err = xml.Unmarshal(body, &tsResponse)
+	if err != nil {
+		return tsResponse, errors.Wrapf(err, "can not xml unmarshall response '%s'", string(body))
+	}
+	return tsResponse, nil
+}
+
+// forEachPage walks all pages of a paged tableau list uri.
+// handle is called with the response of every page and must return the number of items found on that page.
+func (tabl *TabGo) forEachPage(uri string, handle func(TsResponse) int) error {
+	separator := "?"
+	if strings.Contains(uri, "?") {
+		separator = "&"
+	}
+	for pageNum := 1; ; pageNum++ {
+		pageURI := fmt.Sprintf("%s%spageSize=%d&pageNumber=%d", uri, separator, defaultPageSize, pageNum)
+		tsResponse, err := tabl.doTsRequest("GET", pageURI, "")
+		if err != nil {
+			return errors.Wrapf(err, "can not get page %d", pageNum)
+		}
+		count := handle(tsResponse)
+		if count == 0 || pageNum*defaultPageSize >= tsResponse.Pagination.TotalAvailable {
+			return nil
+		}
+	}
+}
+
+// xmlEscape escapes s so it can safely be used as an attribute value in a tsRequest payload
+func xmlEscape(s string) string {
+	var escaped strings.Builder
+	_ = xml.EscapeText(&escaped, []byte(s))
+	return escaped.String()
+}
@@ -0,0 +1,260 @@
+package tableau
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// schedule types
+const (
+	ScheduleTypeExtract      Type = "Extract"
+	ScheduleTypeFlow         Type = "Flow"
+	ScheduleTypeSubscription Type = "Subscription"
+)
+
+// schedule frequencies
+const (
+	FrequencyHourly  Frequency = "Hourly"
+	FrequencyDaily   Frequency = "Daily"
+	FrequencyWeekly  Frequency = "Weekly"
+	FrequencyMonthly Frequency = "Monthly"
+)
+
+// schedule execution orders
+const (
+	ExecutionOrderParallel ExecutionOrder = "Parallel"
+	ExecutionOrderSerial   ExecutionOrder = "Serial"
+)
+
+// scheduleTimeLayout is the layout of the start and end time of a schedule
+const scheduleTimeLayout = "15:04:05"
+
+// ListSchedules returns all schedules of the server, schedules are shared by all sites
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_jobstasksschedules.htm#query_schedules
+func (tabl *TabGo) ListSchedules() ([]ScheduleType, error) {
+	schedules := []ScheduleType{}
+	err := tabl.forEachPage(fmt.Sprintf("%s/schedules", tabl.ApiURL()), func(tsResponse TsResponse) int {
+		schedules = append(schedules, tsResponse.Schedules.Schedule...)
+		return len(tsResponse.Schedules.Schedule)
+	})
+	if err != nil {
+		return schedules, errors.Wrapf(err, "can not list schedules")
+	}
+	return schedules, nil
+}
+
+// GetScheduleByName returns the schedule with the given name
+func (tabl *TabGo) GetScheduleByName(name string) (ScheduleType, error) {
+	schedules, err := tabl.ListSchedules()
+	if err != nil {
+		return ScheduleType{}, err
+	}
+	for _, schedule := range schedules {
+		if schedule.Name == name {
+			return schedule, nil
+		}
+	}
+	return ScheduleType{}, fmt.Errorf("no schedule '%s' found", name)
+}
+
+// CreateSchedule creates a schedule, it needs at least a name, type, frequency and frequency details (start time)
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_jobstasksschedules.htm#create_schedule
+func (tabl *TabGo) CreateSchedule(schedule ScheduleType) (ScheduleType, error) {
+	tsResponse, err := tabl.doTsRequest("POST", fmt.Sprintf("%s/schedules", tabl.ApiURL()), schedulePayload(schedule))
+	if err != nil {
+		return tsResponse.Schedule, errors.Wrapf(err, "can not create schedule '%s'", schedule.Name)
+	}
+	return tsResponse.Schedule, nil
+}
+
+// UpdateSchedule changes the name, state, priority, frequency and/or execution order of a schedule,
+// empty values of update are left unchanged on the server, frequency details are replaced when they have a start time
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_jobstasksschedules.htm#update_schedule
+func (tabl *TabGo) UpdateSchedule(scheduleID string, update ScheduleType) (ScheduleType, error) {
+	tsResponse, err := tabl.doTsRequest("PUT", fmt.Sprintf("%s/schedules/%s", tabl.ApiURL(), scheduleID), schedulePayload(update))
+	if err != nil {
+		return tsResponse.Schedule, errors.Wrapf(err, "can not update schedule '%s'", scheduleID)
+	}
+	return tsResponse.Schedule, nil
+}
+
+// DeleteSchedule deletes a schedule and the tasks of all sites that use it
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_jobstasksschedules.htm#delete_schedule
+func (tabl *TabGo) DeleteSchedule(scheduleID string) error {
+	_, err := tabl.doRequest("DELETE", fmt.Sprintf("%s/schedules/%s", tabl.ApiURL(), scheduleID), "")
+	if err != nil {
+		return errors.Wrapf(err, "can not delete schedule '%s'", scheduleID)
+	}
+	return nil
+}
+
+func schedulePayload(schedule ScheduleType) string {
+	attributes := ""
+	if schedule.Name != "" {
+		attributes += fmt.Sprintf(` name="%s"`, xmlEscape(schedule.Name))
+	}
+	if schedule.State != "" {
+		attributes += fmt.Sprintf(` state="%s"`, schedule.State)
+	}
+	if schedule.Priority != 0 {
+		attributes += fmt.Sprintf(` priority="%d"`, schedule.Priority)
+	}
+	if schedule.Type != "" {
+		attributes += fmt.Sprintf(` type="%s"`, schedule.Type)
+	}
+	if schedule.Frequency != "" {
+		attributes += fmt.Sprintf(` frequency="%s"`, schedule.Frequency)
+	}
+	if schedule.ExecutionOrder != "" {
+		attributes += fmt.Sprintf(` executionOrder="%s"`, schedule.ExecutionOrder)
+	}
+	return fmt.Sprintf(`<tsRequest><schedule%s>%s</schedule></tsRequest>`, attributes, frequencyDetailsElement(schedule.FrequencyDetails))
+}
+
+func frequencyDetailsElement(details FrequencyDetailsType) string {
+	if details.Start.IsZero() {
+		return ""
+	}
+	attributes := fmt.Sprintf(` start="%s"`, details.Start.Format(scheduleTimeLayout))
+	if !details.End.IsZero() {
+		attributes += fmt.Sprintf(` end="%s"`, details.End.Format(scheduleTimeLayout))
+	}
+	intervals := ""
+	for _, interval := range details.Intervals.Interval {
+		intervals += "<interval"
+		if interval.Minutes != 0 {
+			intervals += fmt.Sprintf(` minutes="%d"`, interval.Minutes)
+		}
+		if interval.Hours != 0 {
+			intervals += fmt.Sprintf(` hours="%d"`, interval.Hours)
+		}
+		if interval.WeekDay != "" {
+			intervals += fmt.Sprintf(` weekDay="%s"`, interval.WeekDay)
+		}
+		intervals += " />"
+	}
+	if intervals != "" {
+		intervals = "<intervals>" + intervals + "</intervals>"
+	}
+	return fmt.Sprintf(`<frequencyDetails%s>%s</frequencyDetails>`, attributes, intervals)
+}
+
+// ScheduleSpec describes a schedule in a yaml manifest or on the command line
+// Example yaml:
+//
+//	name: Nightly
+//	frequency: Weekly
+//	start: "02:00"
+//	weekDays: [Monday, Wednesday, Friday]
+//	priority: 50
+type ScheduleSpec struct {
+	Name           string         `yaml:"name"`
+	Type           Type           `yaml:"type,omitempty"`
+	Frequency      Frequency      `yaml:"frequency"`
+	Start          string         `yaml:"start"`
+	End            string         `yaml:"end,omitempty"`
+	Hours          Hours          `yaml:"hours,omitempty"`
+	Minutes        Minutes        `yaml:"minutes,omitempty"`
+	WeekDays       []WeekDay      `yaml:"weekDays,omitempty"`
+	Priority       int            `yaml:"priority,omitempty"`
+	ExecutionOrder ExecutionOrder `yaml:"executionOrder,omitempty"`
+}
+
+// Schedule returns the schedule of the spec, an extract schedule unless the spec has another type.
+// Start and end are times of day: "15:04" or "15:04:05".
+// Monthly schedules are not supported: the generated IntervalType has no monthDay to give the day of the month.
+func (spec ScheduleSpec) Schedule() (ScheduleType, error) {
+	schedule := ScheduleType{
+		Name:           spec.Name,
+		Type:           spec.Type,
+		Frequency:      spec.Frequency,
+		Priority:       spec.Priority,
+		ExecutionOrder: spec.ExecutionOrder,
+	}
+	if schedule.Type == "" {
+		schedule.Type = ScheduleTypeExtract
+	}
+	if spec.Frequency == FrequencyMonthly {
+		return schedule, fmt.Errorf("invalid frequency of schedule '%s', %s schedules are not supported, expecting %s, %s or %s",
+			spec.Name, FrequencyMonthly, FrequencyHourly, FrequencyDaily, FrequencyWeekly)
+	}
+
+	var err error
+	if schedule.FrequencyDetails.Start, err = parseScheduleTime(spec.Start); err != nil {
+		return schedule, errors.Wrapf(err, "invalid start of schedule '%s'", spec.Name)
+	}
+	if spec.End != "" {
+		if schedule.FrequencyDetails.End, err = parseScheduleTime(spec.End); err != nil {
+			return schedule, errors.Wrapf(err, "invalid end of schedule '%s'", spec.Name)
+		}
+	}
+
+	intervals := []IntervalType{}
+	if spec.Hours != 0 {
+		intervals = append(intervals, IntervalType{Hours: spec.Hours})
+	}
+	if spec.Minutes != 0 {
+		intervals = append(intervals, IntervalType{Minutes: spec.Minutes})
+	}
+	for _, weekDay := range spec.WeekDays {
+		intervals = append(intervals, IntervalType{WeekDay: weekDay})
+	}
+	schedule.FrequencyDetails.Intervals.Interval = intervals
+	return schedule, nil
+}
+
+func parseScheduleTime(value string) (time.Time, error) {
+	for _, layout := range []string{scheduleTimeLayout, "15:04"} {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time of day '%s', expecting hh:mm or hh:mm:ss", value)
+}
+
+// scheduleDrift describes the differences between the desired and the actual settings of a schedule
+func scheduleDrift(desired, actual ScheduleType) []string {
+	details := []string{}
+	if desired.Frequency != actual.Frequency {
+		details = append(details, fmt.Sprintf("frequency: %s -> %s", actual.Frequency, desired.Frequency))
+	}
+	if desired.Priority != 0 && desired.Priority != actual.Priority {
+		details = append(details, fmt.Sprintf("priority: %d -> %d", actual.Priority, desired.Priority))
+	}
+	if desired.ExecutionOrder != "" && desired.ExecutionOrder != actual.ExecutionOrder {
+		details = append(details, fmt.Sprintf("executionOrder: %s -> %s", actual.ExecutionOrder, desired.ExecutionOrder))
+	}
+	// intervals are only managed when they are given, tableau adds default intervals to some frequencies
+	if len(desired.FrequencyDetails.Intervals.Interval) == 0 {
+		desired.FrequencyDetails.Intervals = actual.FrequencyDetails.Intervals
+	}
+	if desiredDetails, actualDetails := frequencyDetailsElement(desired.FrequencyDetails), frequencyDetailsElement(actual.FrequencyDetails); desiredDetails != actualDetails {
+		details = append(details, fmt.Sprintf("frequencyDetails: %s -> %s", describeFrequencyDetails(actual.FrequencyDetails), describeFrequencyDetails(desired.FrequencyDetails)))
+	}
+	return details
+}
+
+func describeFrequencyDetails(details FrequencyDetailsType) string {
+	description := details.Start.Format(scheduleTimeLayout)
+	if !details.End.IsZero() {
+		description += "-" + details.End.Format(scheduleTimeLayout)
+	}
+	intervals := []string{}
+	for _, interval := range details.Intervals.Interval {
+		switch {
+		case interval.Hours != 0:
+			intervals = append(intervals, fmt.Sprintf("every %dh", interval.Hours))
+		case interval.Minutes != 0:
+			intervals = append(intervals, fmt.Sprintf("every %dm", interval.Minutes))
+		case interval.WeekDay != "":
+			intervals = append(intervals, string(interval.WeekDay))
+		}
+	}
+	if len(intervals) > 0 {
+		description += " (" + strings.Join(intervals, ", ") + ")"
+	}
+	return description
+}
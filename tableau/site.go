@@ -0,0 +1,155 @@
+package tableau
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// site admin modes
+const (
+	AdminModeContentAndUsers AdminMode = "ContentAndUsers"
+	AdminModeContentOnly     AdminMode = "ContentOnly"
+)
+
+// ListSites returns all sites on the server the signed in user has access to
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_site.htm#query_sites
+func (tabl *TabGo) ListSites() ([]SiteType, error) {
+	sites := []SiteType{}
+	err := tabl.forEachPage(fmt.Sprintf("%s/sites", tabl.ApiURL()), func(tsResponse TsResponse) int {
+		sites = append(sites, tsResponse.Sites.Site...)
+		return len(tsResponse.Sites.Site)
+	})
+	if err != nil {
+		return sites, errors.Wrapf(err, "can not list sites")
+	}
+	return sites, nil
+}
+
+// QuerySite returns the details of a site, including its usage
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_site.htm#query_site
+func (tabl *TabGo) QuerySite(siteID string) (SiteType, error) {
+	tsResponse, err := tabl.doTsRequest("GET", fmt.Sprintf("%s/sites/%s?includeUsageStatistics=true", tabl.ApiURL(), siteID), "")
+	if err != nil {
+		return tsResponse.Site, errors.Wrapf(err, "can not query site '%s'", siteID)
+	}
+	return tsResponse.Site, nil
+}
+
+// QuerySiteByContentURL returns the details of the site with the given content url (the site name used in urls),
+// the empty content url is the default site
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_site.htm#query_site
+func (tabl *TabGo) QuerySiteByContentURL(contentURL string) (SiteType, error) {
+	tsResponse, err := tabl.doTsRequest("GET", fmt.Sprintf("%s/sites/%s?key=contentUrl&includeUsageStatistics=true", tabl.ApiURL(), contentURL), "")
+	if err != nil {
+		return tsResponse.Site, errors.Wrapf(err, "can not query site with content url '%s'", contentURL)
+	}
+	return tsResponse.Site, nil
+}
+
+// CreateSite creates a site on the server, site needs at least a name and a content url.
+// The signed in user must be a server administrator.
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_site.htm#create_site
+func (tabl *TabGo) CreateSite(site SiteType) (SiteType, error) {
+	tsResponse, err := tabl.doTsRequest("POST", fmt.Sprintf("%s/sites", tabl.ApiURL()),
+		fmt.Sprintf(`<tsRequest><site%s /></tsRequest>`, siteAttributes(site)))
+	if err != nil {
+		return tsResponse.Site, errors.Wrapf(err, "can not create site '%s'", site.Name)
+	}
+	return tsResponse.Site, nil
+}
+
+// UpdateSite changes the name, content url, admin mode, quotas, revision history and/or subscription settings of a site,
+// empty values of update are left unchanged on the server (so the boolean settings can only be switched on).
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_site.htm#update_site
+func (tabl *TabGo) UpdateSite(siteID string, update SiteType) (SiteType, error) {
+	tsResponse, err := tabl.doTsRequest("PUT", fmt.Sprintf("%s/sites/%s", tabl.ApiURL(), siteID),
+		fmt.Sprintf(`<tsRequest><site%s /></tsRequest>`, siteAttributes(update)))
+	if err != nil {
+		return tsResponse.Site, errors.Wrapf(err, "can not update site '%s'", siteID)
+	}
+	if siteID == tabl.CurrentSiteID && update.ContentUrl != "" {
+		tabl.CurrentSiteName = update.ContentUrl
+	}
+	return tsResponse.Site, nil
+}
+
+// siteAttributes returns the non empty settings of site as xml attributes
+func siteAttributes(site SiteType) string {
+	attributes := ""
+	if site.Name != "" {
+		attributes += fmt.Sprintf(` name="%s"`, xmlEscape(site.Name))
+	}
+	if site.ContentUrl != "" {
+		attributes += fmt.Sprintf(` contentUrl="%s"`, xmlEscape(site.ContentUrl))
+	}
+	if site.AdminMode != "" {
+		attributes += fmt.Sprintf(` adminMode="%s"`, site.AdminMode)
+	}
+	if site.State != "" {
+		attributes += fmt.Sprintf(` state="%s"`, site.State)
+	}
+	if site.UserQuota != 0 {
+		attributes += fmt.Sprintf(` userQuota="%d"`, site.UserQuota)
+	}
+	if site.StorageQuota != 0 {
+		attributes += fmt.Sprintf(` storageQuota="%d"`, site.StorageQuota)
+	}
+	if site.RevisionHistoryEnabled {
+		attributes += ` revisionHistoryEnabled="true"`
+	}
+	if site.RevisionLimit != "" {
+		attributes += fmt.Sprintf(` revisionLimit="%s"`, site.RevisionLimit)
+	}
+	if site.DisableSubscriptions {
+		attributes += ` disableSubscriptions="true"`
+	}
+	return attributes
+}
+
+// SwitchSite moves the current session to the site with the given content url, without signing out.
+// The current token, site ID and site name are replaced by the ones of the new site.
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_authentication.htm#switch_site
+func (tabl *TabGo) SwitchSite(contentURL string) error {
+	tsResponse, err := tabl.doTsRequest("POST", fmt.Sprintf("%s/auth/switchSite", tabl.ApiURL()),
+		fmt.Sprintf(`<tsRequest><site contentUrl="%s" /></tsRequest>`, xmlEscape(contentURL)))
+	if err != nil {
+		return errors.Wrapf(err, "can not switch to site '%s'", contentURL)
+	}
+
+	tabl.CurrentToken = tsResponse.Credentials.Token
+	tabl.CurrentSiteID = string(tsResponse.Credentials.Site.Id)
+	tabl.CurrentSiteName = contentURL
+	tabl.projectTree = nil
+	return nil
+}
+
+// ForEachSite switches to every site on the server in turn and calls visit,
+// afterwards the session is switched back to the site it started on.
+// It stops at the first error visit returns.
+func (tabl *TabGo) ForEachSite(visit func(site SiteType) error) error {
+	sites, err := tabl.ListSites()
+	if err != nil {
+		return err
+	}
+	startSite := tabl.CurrentSiteName
+
+	for _, site := range sites {
+		if site.ContentUrl != tabl.CurrentSiteName {
+			if err = tabl.SwitchSite(site.ContentUrl); err != nil {
+				break
+			}
+		}
+		if err = visit(site); err != nil {
+			err = errors.Wrapf(err, "can not process site '%s'", site.ContentUrl)
+			break
+		}
+	}
+
+	if tabl.CurrentSiteName != startSite {
+		if switchErr := tabl.SwitchSite(startSite); switchErr != nil && err == nil {
+			err = switchErr
+		}
+	}
+	return err
+}
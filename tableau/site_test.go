@@ -0,0 +1,89 @@
+package tableau
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestSiteAttributes(t *testing.T) {
+	tests := []struct {
+		site       SiteType
+		attributes string
+	}{
+		{SiteType{}, ""},
+		{SiteType{Name: "Finance & Co", ContentUrl: "finance"}, ` name="Finance &amp; Co" contentUrl="finance"`},
+		{SiteType{AdminMode: "ContentOnly", State: "Suspended", UserQuota: 25, StorageQuota: 1024},
+			` adminMode="ContentOnly" state="Suspended" userQuota="25" storageQuota="1024"`},
+		{SiteType{RevisionHistoryEnabled: true, RevisionLimit: "10", DisableSubscriptions: true},
+			` revisionHistoryEnabled="true" revisionLimit="10" disableSubscriptions="true"`},
+	}
+	for _, test := range tests {
+		if attributes := siteAttributes(test.site); attributes != test.attributes {
+			t.Errorf("siteAttributes(%+v) = %q, expecting %q", test.site, attributes, test.attributes)
+		}
+	}
+}
+
+func TestForEachSite(t *testing.T) {
+	contentURLRe := regexp.MustCompile(`contentUrl="([^"]*)"`)
+	switches := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := ""
+		switch r.URL.Path {
+		case "/api/3.6/sites":
+			body = `<sites><site id="s1" contentUrl="" /><site id="s2" contentUrl="finance" /><site id="s3" contentUrl="hr" /></sites>`
+		case "/api/3.6/auth/switchSite":
+			payload, _ := ioutil.ReadAll(r.Body)
+			contentURL := contentURLRe.FindStringSubmatch(string(payload))[1]
+			switches = append(switches, contentURL)
+			body = fmt.Sprintf(`<credentials token="t-%s"><site id="id-%s" /></credentials>`, contentURL, contentURL)
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL)
+		}
+		w.Write([]byte(`<tsResponse xmlns="http://tableau.com/api">` + body + `</tsResponse>`))
+	}))
+	defer server.Close()
+
+	finance := "finance"
+	tests := []struct {
+		name string
+		// fail is the site whose visit fails, nil when all visits succeed
+		fail     *string
+		visited  []string
+		switches []string
+	}{
+		{"all sites", nil, []string{"", "finance", "hr"}, []string{"", "finance", "hr", "finance"}},
+		{"stops at the first error", &finance, []string{"", "finance"}, []string{"", "finance"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			switches = []string{}
+			tabl := &TabGo{ServerURL: server.URL, ApiVersion: "3.6", CurrentSiteID: "id-finance", CurrentSiteName: "finance"}
+			visited := []string{}
+			err := tabl.ForEachSite(func(site SiteType) error {
+				visited = append(visited, site.ContentUrl)
+				if test.fail != nil && site.ContentUrl == *test.fail {
+					return fmt.Errorf("visit failed")
+				}
+				return nil
+			})
+			if (err != nil) != (test.fail != nil) {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(visited, test.visited) {
+				t.Errorf("visited %q, expecting %q", visited, test.visited)
+			}
+			if !reflect.DeepEqual(switches, test.switches) {
+				t.Errorf("switched to %q, expecting %q", switches, test.switches)
+			}
+			if tabl.CurrentSiteName != "finance" || tabl.CurrentSiteID != "id-finance" {
+				t.Errorf("session ends on site '%s' (%s), expecting finance", tabl.CurrentSiteName, tabl.CurrentSiteID)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package tableau
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// subscription content types
+const (
+	SubscriptionContentView     Type = "View"
+	SubscriptionContentWorkbook Type = "Workbook"
+)
+
+// ListSubscriptions returns the subscriptions of the current site
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_subscriptions.htm#query_subscriptions
+func (tabl *TabGo) ListSubscriptions() ([]SubscriptionType, error) {
+	subscriptions := []SubscriptionType{}
+	err := tabl.forEachPage(fmt.Sprintf("%s/subscriptions", tabl.SiteURL()), func(tsResponse TsResponse) int {
+		subscriptions = append(subscriptions, tsResponse.Subscriptions.Subscription...)
+		return len(tsResponse.Subscriptions.Subscription)
+	})
+	if err != nil {
+		return subscriptions, errors.Wrapf(err, "can not list subscriptions")
+	}
+	return subscriptions, nil
+}
+
+// QuerySubscription returns a subscription
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_subscriptions.htm#query_subscription
+func (tabl *TabGo) QuerySubscription(subscriptionID string) (SubscriptionType, error) {
+	tsResponse, err := tabl.doTsRequest("GET", fmt.Sprintf("%s/subscriptions/%s", tabl.SiteURL(), subscriptionID), "")
+	if err != nil {
+		return tsResponse.Subscription, errors.Wrapf(err, "can not query subscription '%s'", subscriptionID)
+	}
+	return tsResponse.Subscription, nil
+}
+
+// CreateSubscription subscribes a user to a view or workbook (Content.Id and Content.Type) on a schedule (Schedule.Id),
+// the subscription needs a subject
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_subscriptions.htm#create_subscription
+func (tabl *TabGo) CreateSubscription(subscription SubscriptionType) (SubscriptionType, error) {
+	tsResponse, err := tabl.doTsRequest("POST", fmt.Sprintf("%s/subscriptions", tabl.SiteURL()),
+		fmt.Sprintf(`<tsRequest><subscription%s><content id="%s" type="%s" /><schedule id="%s" /><user id="%s" /></subscription></tsRequest>`,
+			subscriptionAttributes(subscription), subscription.Content.Id, subscription.Content.Type, subscription.Schedule.Id, subscription.User.Id))
+	if err != nil {
+		return tsResponse.Subscription, errors.Wrapf(err, "can not create subscription '%s'", subscription.Subject)
+	}
+	return tsResponse.Subscription, nil
+}
+
+// UpdateSubscription changes the subject, attachments and, when it has an id, the schedule of a subscription
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_subscriptions.htm#update_subscription
+func (tabl *TabGo) UpdateSubscription(subscriptionID string, subscription SubscriptionType) (SubscriptionType, error) {
+	schedule := ""
+	if subscription.Schedule.Id != "" {
+		schedule = fmt.Sprintf(`<schedule id="%s" />`, subscription.Schedule.Id)
+	}
+	tsResponse, err := tabl.doTsRequest("PUT", fmt.Sprintf("%s/subscriptions/%s", tabl.SiteURL(), subscriptionID),
+		fmt.Sprintf(`<tsRequest><subscription%s>%s</subscription></tsRequest>`, subscriptionAttributes(subscription), schedule))
+	if err != nil {
+		return tsResponse.Subscription, errors.Wrapf(err, "can not update subscription '%s'", subscriptionID)
+	}
+	return tsResponse.Subscription, nil
+}
+
+// DeleteSubscription deletes a subscription
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_subscriptions.htm#delete_subscription
+func (tabl *TabGo) DeleteSubscription(subscriptionID string) error {
+	_, err := tabl.doRequest("DELETE", fmt.Sprintf("%s/subscriptions/%s", tabl.SiteURL(), subscriptionID), "")
+	if err != nil {
+		return errors.Wrapf(err, "can not delete subscription '%s'", subscriptionID)
+	}
+	return nil
+}
+
+func subscriptionAttributes(subscription SubscriptionType) string {
+	return fmt.Sprintf(` subject="%s" attachImage="%t" attachPdf="%t"`, xmlEscape(subscription.Subject), subscription.AttachImage, subscription.AttachPdf)
+}
+
+// SubscriptionCopy is a subscription of a workbook or one of its views and the subscription that copies it
+// to the replacement of the workbook, Skipped tells why it is not copied
+type SubscriptionCopy struct {
+	Source  SubscriptionType
+	Target  SubscriptionType
+	Skipped string
+}
+
+func (subscriptionCopy SubscriptionCopy) String() string {
+	source := subscriptionCopy.Source
+	description := fmt.Sprintf("%s (%s %s, %s, %s)", source.Subject, source.Content.Type, nameOrID(string(source.Content.Name), source.Content.Id),
+		nameOrID(source.User.Name, source.User.Id), nameOrID(source.Schedule.Name, source.Schedule.Id))
+	if subscriptionCopy.Skipped != "" {
+		return fmt.Sprintf("! %s: %s", description, subscriptionCopy.Skipped)
+	}
+	return "+ " + description
+}
+
+// PlanSubscriptionCopy returns the subscriptions of a workbook and its views, with the subscriptions that copy them
+// to another workbook, typically its republished replacement. Views are matched by name.
+// Subscriptions the target already has (same user, schedule and content) are skipped.
+func (tabl *TabGo) PlanSubscriptionCopy(fromWorkbookID, toWorkbookID string) ([]SubscriptionCopy, error) {
+	copies := []SubscriptionCopy{}
+	fromViews, err := tabl.ListWorkbookViews(fromWorkbookID)
+	if err != nil {
+		return copies, err
+	}
+	toViews, err := tabl.ListWorkbookViews(toWorkbookID)
+	if err != nil {
+		return copies, err
+	}
+	subscriptions, err := tabl.ListSubscriptions()
+	if err != nil {
+		return copies, err
+	}
+
+	// the target content of the source content
+	targets := map[ResourceIdType]ResourceIdType{ResourceIdType(fromWorkbookID): ResourceIdType(toWorkbookID)}
+	viewNames := make(map[ResourceIdType]string)
+	for _, from := range fromViews {
+		viewNames[from.Id] = from.Name
+		for _, to := range toViews {
+			if to.Name == from.Name {
+				targets[from.Id] = to.Id
+			}
+		}
+	}
+
+	existing := make(map[string]bool)
+	for _, subscription := range subscriptions {
+		existing[subscriptionKey(subscription)] = true
+	}
+
+	for _, subscription := range subscriptions {
+		content := subscription.Content
+		isSource := (content.Type == SubscriptionContentWorkbook && string(content.Id) == fromWorkbookID) ||
+			(content.Type == SubscriptionContentView && viewNames[content.Id] != "")
+		if !isSource {
+			continue
+		}
+
+		subscriptionCopy := SubscriptionCopy{Source: subscription}
+		target, found := targets[content.Id]
+		if !found {
+			subscriptionCopy.Skipped = fmt.Sprintf("view '%s' does not exist in the target workbook", viewNames[content.Id])
+			copies = append(copies, subscriptionCopy)
+			continue
+		}
+		subscriptionCopy.Target = SubscriptionType{
+			Content:     SubscriptionContentType{Id: target, Type: content.Type, Name: content.Name},
+			Schedule:    subscription.Schedule,
+			User:        subscription.User,
+			Subject:     subscription.Subject,
+			AttachImage: subscription.AttachImage,
+			AttachPdf:   subscription.AttachPdf,
+		}
+		if existing[subscriptionKey(subscriptionCopy.Target)] {
+			subscriptionCopy.Skipped = "target already has this subscription"
+		}
+		copies = append(copies, subscriptionCopy)
+	}
+	return copies, nil
+}
+
+// subscriptionKey identifies what a subscription sends to whom and when
+func subscriptionKey(subscription SubscriptionType) string {
+	return fmt.Sprintf("%s/%s/%s/%s", subscription.Content.Type, subscription.Content.Id, subscription.User.Id, subscription.Schedule.Id)
+}
+
+// CopySubscriptions creates the target subscriptions of copies that are not skipped, in order
+func (tabl *TabGo) CopySubscriptions(copies []SubscriptionCopy) error {
+	for _, subscriptionCopy := range copies {
+		if subscriptionCopy.Skipped != "" {
+			continue
+		}
+		if _, err := tabl.CreateSubscription(subscriptionCopy.Target); err != nil {
+			return errors.Wrapf(err, "can not apply '%s'", subscriptionCopy)
+		}
+	}
+	return nil
+}
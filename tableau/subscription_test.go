@@ -0,0 +1,53 @@
+package tableau
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPlanSubscriptionCopy(t *testing.T) {
+	tabl, cleanup := newTestServer(t, map[string]string{
+		"/workbooks/old/views": `<views><view id="v1" name="Overview" /><view id="v2" name="Details" /></views>`,
+		"/workbooks/new/views": `<views><view id="v3" name="Overview" /></views>`,
+		"/subscriptions": `<subscriptions>
+			<subscription id="s1" subject="Daily sales"><content id="old" type="Workbook" name="Sales" /><schedule id="daily" name="Daily" /><user id="u1" name="jdoe" /></subscription>
+			<subscription id="s2" subject="Overview"><content id="v1" type="View" /><schedule id="daily" /><user id="u2" name="asmith" /></subscription>
+			<subscription id="s3" subject="Details"><content id="v2" type="View" name="Details" /><schedule id="weekly" name="Weekly" /><user id="u1" name="jdoe" /></subscription>
+			<subscription id="s4" subject="Other"><content id="w9" type="Workbook" /><schedule id="daily" /><user id="u1" /></subscription>
+			<subscription id="s5" subject="Overview, already copied"><content id="v3" type="View" /><schedule id="daily" /><user id="u2" /></subscription>
+		</subscriptions>`,
+	})
+	defer cleanup()
+
+	copies, err := tabl.PlanSubscriptionCopy("old", "new")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := []string{}
+	for _, subscriptionCopy := range copies {
+		lines = append(lines, subscriptionCopy.String())
+	}
+	expected := strings.Join([]string{
+		"+ Daily sales (Workbook Sales, jdoe, Daily)",
+		"! Overview (View v1, asmith, daily): target already has this subscription",
+		"! Details (View Details, jdoe, Weekly): view 'Details' does not exist in the target workbook",
+	}, "\n")
+	if plan := strings.Join(lines, "\n"); plan != expected {
+		t.Errorf("plan:\n%s\nexpecting:\n%s", plan, expected)
+	}
+
+	target := copies[0].Target
+	if target.Content.Id != "new" || target.Schedule.Id != "daily" || target.User.Id != "u1" || target.Subject != "Daily sales" {
+		t.Errorf("target of the workbook subscription = %+v", target)
+	}
+	if key := subscriptionKey(copies[1].Target); key != "View/v3/u2/daily" {
+		t.Errorf("key of the view copy = %s, expecting View/v3/u2/daily", key)
+	}
+}
+
+func TestSubscriptionAttributes(t *testing.T) {
+	attributes := subscriptionAttributes(SubscriptionType{Subject: `Sales & "Margin"`, AttachPdf: true})
+	if expected := ` subject="Sales &amp; &#34;Margin&#34;" attachImage="false" attachPdf="true"`; attributes != expected {
+		t.Errorf("attributes = %s, expecting %s", attributes, expected)
+	}
+}
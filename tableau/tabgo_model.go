@@ -29,6 +29,13 @@ type TabGo struct {
 	CurrentToken    string
 	CurrentSiteID   string
 	CurrentSiteName string
+
+	// NewProjectPermissions, when set, is applied to every project created in this session,
+	// including the projects created by GetProjectID and PublishDocument
+	NewProjectPermissions *PermissionTemplate
+
+	// projectTree caches the project hierarchy of the current site, cfr GetProjectID
+	projectTree []*ProjectNode
 }
 
 type CredentialHolder struct {
@@ -146,6 +153,7 @@ func (tabl *TabGo) Signin(username, password, siteName string) error {
 	tabl.CurrentToken = credentialHolder.Credentials.Token
 	tabl.CurrentSiteID = credentialHolder.Credentials.Site.ID
 	tabl.CurrentSiteName = siteName
+	tabl.projectTree = nil
 	return nil
 }
 
@@ -180,9 +188,13 @@ func (tabl *TabGo) Signout() error {
 	tabl.CurrentToken = ""
 	tabl.CurrentSiteID = ""
 	tabl.CurrentSiteName = ""
+	tabl.projectTree = nil
 	return nil
 }
 
+// PublishDocument publishes a workbook or datasource to the project at projectName.
+// The config file of the document (cfr DocumentConfig) can give the project when projectName is empty,
+// override target connections and extract, tag, schedule and set permissions on the published document.
 // cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_concepts_publish.htm
 func (tabl *TabGo) PublishDocument(documentPath, projectName string, targetConnectionFinder ConnectionFinder) (TsResponse, error) {
 
@@ -195,6 +207,18 @@ func (tabl *TabGo) PublishDocument(documentPath, projectName string, targetConne
 		documentName = documentName[1:]
 	}
 
+	documentConfig, _, err := LoadDocumentConfig(documentPath)
+	if err != nil {
+		return tsResponse, err
+	}
+	if projectName == "" {
+		projectName = documentConfig.Project
+	}
+	if projectName == "" {
+		return tsResponse, fmt.Errorf("no project to publish %s to, expecting a project argument or a project in its config", documentPath)
+	}
+	targetConnectionFinder = documentConfig.ConnectionFinder(targetConnectionFinder)
+
 	projectID, err := tabl.GetProjectID(projectName)
 	if err != nil {
 		return tsResponse, errors.Wrapf(err, "can not get project id")
@@ -212,11 +236,19 @@ func (tabl *TabGo) PublishDocument(documentPath, projectName string, targetConne
 		return tsResponse, errors.Wrapf(err, "can not compile regex")
 	}
 
-	var tmpFile *os.File
-
 	switch documentExtension {
 	case "twb", "twbx":
+		// Write a temporary document in which the schema and connections and schema references have been replaced in the xml
+		// and upload this document.
+		// So no more need to pass connections in the payload? Yes, because we want the password to be embedded !
 		var connections string
+		tmpFile, err := ioutil.TempFile("", fmt.Sprintf("*%s", filepath.Ext(documentPath)))
+		if err != nil {
+			return tsResponse, errors.Wrapf(err, "can not create tmpfiles")
+		}
+		tmpFile.Close()
+		defer os.Remove(tmpFile.Name())
+
 		if documentExtension == "twbx" {
 
 			// twbx is a zip containing twb files ...
@@ -232,109 +264,32 @@ func (tabl *TabGo) PublishDocument(documentPath, projectName string, targetConne
 				return tsResponse, errors.Wrapf(err, "can not unzip twbx")
 			}
 
-			var connections string
 			twbxFiles, err := ioutil.ReadDir(tmpdir)
 			if err != nil {
 				return tsResponse, errors.Wrapf(err, "can not read files from dir '%s'", tmpdir)
 			}
 			for _, fi := range twbxFiles {
-				if "twb" == filepath.Ext(fi.Name()) {
-					fileConnections, err := ConnectionLinesXml(filepath.Join(tmpdir, fi.Name()), tsResponse, captionRe, targetConnectionFinder)
+				if ".twb" == filepath.Ext(fi.Name()) {
+					twbPath := filepath.Join(tmpdir, fi.Name())
+					fileConnections, err := ConnectionLinesXml(twbPath, tsResponse, captionRe, targetConnectionFinder)
 					if err != nil {
 						return tsResponse, errors.Wrapf(err, "can not get ConnectionLines")
 					}
 					connections += fileConnections
-				}
-			}
-
-		} else {
-
-			// Write a temporary file in which the schema and connections and schema references have been replaced in the xml
-			// and upload this file.
-			// So no more need to pass connections in the payload? Yes, because we want the password to be embedded !
-
-			documentContent, err := ioutil.ReadFile(documentPath)
-			if err != nil {
-				return tsResponse, errors.Wrapf(err, "can not read file %s", documentPath)
-			}
-
-			wb := Workbook{}
-			err = xml.Unmarshal(documentContent, &wb)
-			if err != nil {
-				return tsResponse, errors.Wrapf(err, "can not xml.Unmarshall  workbook %s", documentPath)
-			}
-
-			documentString := string(documentContent)
-			documentParts := []string{}
-			documentPos := 0
-
-			connectionSchema := make(map[string]string)
-			for _, ds := range wb.Datasources.Datasource {
-				for _, nc := range ds.Connection.NamedConnections.NamedConnection {
-					if nc.Caption == "" {
-						continue
-					}
-					targetConnection, err := targetConnectionFinder.FindConnection(nc.Caption)
-					if err != nil {
-						return tsResponse, errors.Wrapf(err, "can not find targetConnection for caption '%s'", nc.Caption)
-					}
-					if targetConnection.Schema != "" {
-						connectionSchema[nc.Name] = targetConnection.Schema
+					if err = tabl.rewriteWorkbookFile(twbPath, twbPath, targetConnectionFinder); err != nil {
+						return tsResponse, err
 					}
-
-					startNameConnectionRe := regexp.MustCompile(fmt.Sprintf(`(?s)<named-connection [^>]*name='%s`, nc.Name))
-
-					startPosition := startNameConnectionRe.FindStringIndex(documentString)
-					documentParts = append(documentParts, documentString[documentPos:startPosition[0]-1])
-					documentPos = startPosition[0]
-
-					endNameConnectionPos := strings.Index(documentString[documentPos:], "</named-connection>")
-					endNameConnectionPos += len("</named-connection>")
-					documentParts = append(documentParts, documentString[documentPos:documentPos+endNameConnectionPos])
-
-					newNamedConnection := strings.ReplaceAll(documentParts[len(documentParts)-1], fmt.Sprintf(`schema='%s'`, nc.Connection.Schema), fmt.Sprintf(`schema='%s'`, targetConnection.Schema))
-					newNamedConnection = strings.ReplaceAll(newNamedConnection, fmt.Sprintf(`server='%s'`, nc.Connection.Server), fmt.Sprintf(`server='%s'`, targetConnection.ServerAddress))
-					newNamedConnection = strings.ReplaceAll(newNamedConnection, fmt.Sprintf(`username='%s'`, nc.Connection.Username), fmt.Sprintf(`username='%s'`, targetConnection.UserName))
-					documentParts[len(documentParts)-1] = newNamedConnection
-					documentPos += endNameConnectionPos
 				}
 			}
-			if documentPos > 0 {
-				documentPos += 1
-			}
-			documentParts = append(documentParts, documentString[documentPos:len(documentString)-1])
 
-			documentString = ""
-			for _, part := range documentParts {
-				documentString += part
+			if err = Zip(tmpdir, tmpFile.Name()); err != nil {
+				return tsResponse, errors.Wrapf(err, "can not zip twbx")
 			}
 
-			for name, schema := range connectionSchema {
-				relationRE := regexp.MustCompile(fmt.Sprintf(`(?s)<relation[^/]*connection='%s'[^/]*table=['"]\[([^\]]*)\][^/]*/>`, name))
-				for _, relationMatches := range relationRE.FindAllStringSubmatch(documentString, -1) {
-					_ = relationMatches
-					newRelation := strings.ReplaceAll(relationMatches[0], relationMatches[1], schema)
-					documentString = strings.ReplaceAll(documentString, relationMatches[0], newRelation)
-				}
-			}
+		} else {
 
-			// replace site in repository-location
-			repositoryLocationRE := regexp.MustCompile(`(?s)<repository-location[^>]*site='([^']*)'`)
-			for _, repositoryLocationMatches := range repositoryLocationRE.FindAllStringSubmatch(documentString, -1) {
-				newRelation := strings.ReplaceAll(repositoryLocationMatches[0], repositoryLocationMatches[1], tabl.CurrentSiteName)
-				if repositoryLocationMatches[0] != newRelation {
-					documentString = strings.ReplaceAll(documentString, repositoryLocationMatches[0], newRelation)
-				}
-			}
-
-			documentContent = []byte(documentString)
-			tmpFile, err = ioutil.TempFile("", fmt.Sprintf("*%s", filepath.Ext(documentPath)))
-			if err != nil {
-				return tsResponse, errors.Wrapf(err, "can not create tmpfiles")
-			}
-			err = ioutil.WriteFile(tmpFile.Name(), documentContent, 0755)
-			if err != nil {
-				return tsResponse, errors.Wrapf(err, "")
+			if err = tabl.rewriteWorkbookFile(documentPath, tmpFile.Name(), targetConnectionFinder); err != nil {
+				return tsResponse, err
 			}
 
 			connections, err = ConnectionLinesXml(documentPath, tsResponse, captionRe, targetConnectionFinder)
@@ -343,18 +298,21 @@ func (tabl *TabGo) PublishDocument(documentPath, projectName string, targetConne
 			}
 		}
 
-		if tmpFile != nil {
-			defer os.Remove(tmpFile.Name())
-		}
-
 		tsRequest := fmt.Sprintf(`<tsRequest><workbook name="%s" showTabs="true">%s<project id="%s"/></workbook></tsRequest>`, documentName, connections, projectID)
 
-		return uploadFile("request_payload", "text/xml", tsRequest, "tableau_workbook", tmpFile.Name(),
+		tsResponse, err = uploadFile("request_payload", "text/xml", tsRequest, "tableau_workbook", tmpFile.Name(),
 			fmt.Sprintf("%s/sites/%s/workbooks?workbookType=%s&overwrite=true", tabl.ApiURL(), tabl.CurrentSiteID, documentExtension),
 			documentExtension,
 			tabl.CurrentToken)
+		if err != nil {
+			return tsResponse, errors.Wrapf(err, "can not upload workbook")
+		}
+
+		return tsResponse, tabl.applyDocumentConfig(KindWorkbook, string(tsResponse.Workbook.Id), documentName, documentConfig)
 
 	case "tds", "tdsx":
+		tabl.warnUncertifiedOverwrite(documentName, projectID, documentConfig)
+
 		//// Following works, but does not embed connection password
 		tsRequest := fmt.Sprintf(`<tsRequest><datasource name="%s"><project id="%s"/></datasource></tsRequest>`, documentName, projectID)
 
@@ -397,46 +355,96 @@ func (tabl *TabGo) PublishDocument(documentPath, projectName string, targetConne
 			}
 		}
 
-		// Extract Data ?  Yes if we have a *.tds.json file in the same folder as the tds with ExtractDataSource = true
-		// Example documentConfig json: {"ExtractDataSourceData":true,"EncryptData":false}
-		documentConfigPath := documentConfigPath(documentPath)
-		if fileExists(documentConfigPath) {
-			jsonContent, err := ioutil.ReadFile(documentConfigPath)
+		return tsResponse, tabl.applyDocumentConfig(KindDatasource, datasourceId, documentName, documentConfig)
+	default:
+		return tsResponse, fmt.Errorf("invalid document extension '', expecting one of 'tds', 'tdsx', 'twb', 'twbx'")
+	}
+
+}
+
+// rewriteWorkbookFile writes the workbook (twb) at documentPath to targetPath, with the server, schema and username of its
+// named connections replaced by the ones of the target connections, its table schemas replaced accordingly
+// and the site of its repository locations replaced by the current site
+func (tabl *TabGo) rewriteWorkbookFile(documentPath, targetPath string, targetConnectionFinder ConnectionFinder) error {
+	documentContent, err := ioutil.ReadFile(documentPath)
+	if err != nil {
+		return errors.Wrapf(err, "can not read file %s", documentPath)
+	}
+
+	wb := Workbook{}
+	err = xml.Unmarshal(documentContent, &wb)
+	if err != nil {
+		return errors.Wrapf(err, "can not xml.Unmarshall  workbook %s", documentPath)
+	}
+
+	documentString := string(documentContent)
+	documentParts := []string{}
+	documentPos := 0
+
+	connectionSchema := make(map[string]string)
+	for _, ds := range wb.Datasources.Datasource {
+		for _, nc := range ds.Connection.NamedConnections.NamedConnection {
+			if nc.Caption == "" {
+				continue
+			}
+			targetConnection, err := targetConnectionFinder.FindConnection(nc.Caption)
 			if err != nil {
-				return tsResponse, errors.Wrapf(err, "can not read %s", documentConfigPath)
+				return errors.Wrapf(err, "can not find targetConnection for caption '%s'", nc.Caption)
 			}
-			type DocumentConfig struct {
-				ExtractDataSourceData bool
-				EncryptData           bool
+			if targetConnection.Schema != "" {
+				connectionSchema[nc.Name] = targetConnection.Schema
 			}
-			var documentConfig DocumentConfig
 
-			err = json.NewDecoder(bytes.NewReader(jsonContent)).Decode(&documentConfig)
-			if err != nil {
-				return tsResponse, errors.Wrapf(err, "can not json decode")
-			}
-			if documentConfig.ExtractDataSourceData {
-				_ = tabl.DeleteExtractedDatasourceData(datasourceId)
-				//if err != nil {
-				//	return tsResponse, errors.Wrapf(err, "can not delete extracted data for datasource '%s'", documentName)
-				//}
-
-				err = tabl.ExtractDatasourceData(datasourceId, documentConfig.EncryptData)
-				if err != nil {
-					return tsResponse, errors.Wrapf(err, "can not extract data for datasource '%s'", documentName)
-				}
+			startNameConnectionRe := regexp.MustCompile(fmt.Sprintf(`(?s)<named-connection [^>]*name='%s`, nc.Name))
+
+			startPosition := startNameConnectionRe.FindStringIndex(documentString[documentPos:])
+			if startPosition == nil {
+				return fmt.Errorf("no named connection '%s' found in %s", nc.Name, documentPath)
 			}
+			startPosition[0] += documentPos
+			documentParts = append(documentParts, documentString[documentPos:startPosition[0]])
+			documentPos = startPosition[0]
+
+			endNameConnectionPos := strings.Index(documentString[documentPos:], "</named-connection>")
+			endNameConnectionPos += len("</named-connection>")
+			documentParts = append(documentParts, documentString[documentPos:documentPos+endNameConnectionPos])
+
+			newNamedConnection := strings.ReplaceAll(documentParts[len(documentParts)-1], fmt.Sprintf(`schema='%s'`, nc.Connection.Schema), fmt.Sprintf(`schema='%s'`, targetConnection.Schema))
+			newNamedConnection = strings.ReplaceAll(newNamedConnection, fmt.Sprintf(`server='%s'`, nc.Connection.Server), fmt.Sprintf(`server='%s'`, targetConnection.ServerAddress))
+			newNamedConnection = strings.ReplaceAll(newNamedConnection, fmt.Sprintf(`username='%s'`, nc.Connection.Username), fmt.Sprintf(`username='%s'`, targetConnection.UserName))
+			documentParts[len(documentParts)-1] = newNamedConnection
+			documentPos += endNameConnectionPos
 		}
+	}
+	documentParts = append(documentParts, documentString[documentPos:])
 
-		return tsResponse, nil
-	default:
-		return tsResponse, fmt.Errorf("invalid document extension '', expecting one of 'tds', 'tdsx', 'twb', 'twbx'")
+	documentString = ""
+	for _, part := range documentParts {
+		documentString += part
 	}
 
-}
+	for name, schema := range connectionSchema {
+		relationRE := regexp.MustCompile(fmt.Sprintf(`(?s)<relation[^/]*connection='%s'[^/]*table=['"]\[([^\]]*)\][^/]*/>`, name))
+		for _, relationMatches := range relationRE.FindAllStringSubmatch(documentString, -1) {
+			_ = relationMatches
+			newRelation := strings.ReplaceAll(relationMatches[0], relationMatches[1], schema)
+			documentString = strings.ReplaceAll(documentString, relationMatches[0], newRelation)
+		}
+	}
+
+	// replace site in repository-location
+	repositoryLocationRE := regexp.MustCompile(`(?s)<repository-location[^>]*site='([^']*)'`)
+	for _, repositoryLocationMatches := range repositoryLocationRE.FindAllStringSubmatch(documentString, -1) {
+		newRelation := strings.ReplaceAll(repositoryLocationMatches[0], repositoryLocationMatches[1], tabl.CurrentSiteName)
+		if repositoryLocationMatches[0] != newRelation {
+			documentString = strings.ReplaceAll(documentString, repositoryLocationMatches[0], newRelation)
+		}
+	}
 
-func documentConfigPath(documentPath string) string {
-	return documentPath + ".json"
+	if err = ioutil.WriteFile(targetPath, []byte(documentString), 0755); err != nil {
+		return errors.Wrapf(err, "can not write %s", targetPath)
+	}
+	return nil
 }
 
 func ConnectionLinesXml(documentPath string, tsResponse TsResponse, captionRe *regexp.Regexp, targetConnectionFinder ConnectionFinder) (string, error) {
@@ -467,12 +475,43 @@ func ConnectionLinesXml(documentPath string, tsResponse TsResponse, captionRe *r
 // NamedConnections returns a map of Connections (as key) with the value being the caption of the named connection
 func GetNamedConnections(documentPath string, namedConnectionsRe *regexp.Regexp) (map[string]string, error) {
 	namedConnections := make(map[string]string)
-	documentContent, err := ioutil.ReadFile(documentPath)
+
+	tdsPath := documentPath
+	if strings.HasSuffix(documentPath, ".tdsx") {
+		// tdsx is a zip containing the tds file next to its extracts
+		tmpdir, err := ioutil.TempDir("", "tdsx")
+		if err != nil {
+			return namedConnections, errors.Wrapf(err, "can not create temporary directory")
+		}
+		defer os.RemoveAll(tmpdir)
+
+		if err = Unzip(documentPath, tmpdir); err != nil {
+			return namedConnections, errors.Wrapf(err, "can not unzip tdsx '%s'", documentPath)
+		}
+		tdsPath = ""
+		err = filepath.Walk(tmpdir, func(path string, info os.FileInfo, err error) error {
+			if err == nil && tdsPath == "" && !info.IsDir() && filepath.Ext(path) == ".tds" {
+				tdsPath = path
+			}
+			return err
+		})
+		if err != nil {
+			return namedConnections, errors.Wrapf(err, "can not walk unzipped tdsx '%s'", documentPath)
+		}
+		if tdsPath == "" {
+			return namedConnections, fmt.Errorf("no tds file found in tdsx '%s'", documentPath)
+		}
+	}
+
+	documentContent, err := ioutil.ReadFile(tdsPath)
 	if err != nil {
 		return namedConnections, errors.Wrapf(err, "can not read content from '%s'", documentPath)
 	}
 
 	matches := namedConnectionsRe.FindStringSubmatch(string(documentContent))
+	if matches == nil {
+		return namedConnections, fmt.Errorf("no named connections found in '%s'", documentPath)
+	}
 
 	parsedNamedConnections := NamedConnections{}
 	err = xml.Unmarshal([]byte(matches[0]), &parsedNamedConnections)
@@ -527,7 +566,7 @@ func (tabl *TabGo) EmbedDatasourceConnection(datasourceId string, connection Con
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("expected success response %s, but got %s: %s", http.StatusOK, resp.StatusCode, body)
+		return fmt.Errorf("expected success response %d, but got %d: %s", http.StatusOK, resp.StatusCode, body)
 	}
 	return nil
 }
@@ -557,7 +596,7 @@ func (tabl *TabGo) ExtractDatasourceData(datasourceId string, encrypt bool) erro
 	}
 
 	if resp.StatusCode <= http.StatusOK || resp.StatusCode >= http.StatusIMUsed {
-		return fmt.Errorf("expected success-range response (2xx), but got %s: %s", resp.StatusCode, body)
+		return fmt.Errorf("expected success-range response (2xx), but got %d: %s", resp.StatusCode, body)
 	}
 	log.Printf("Data for datasource %s extracted successfully (encrypted: %s)", datasourceId, strconv.FormatBool(encrypt))
 	return nil
@@ -588,7 +627,7 @@ func (tabl *TabGo) DeleteExtractedDatasourceData(datasourceId string) error {
 	}
 
 	if resp.StatusCode <= http.StatusOK || resp.StatusCode >= http.StatusIMUsed {
-		return fmt.Errorf("expected success-range response (2xx), but got %s: %s", resp.StatusCode, body)
+		return fmt.Errorf("expected success-range response (2xx), but got %d: %s", resp.StatusCode, body)
 	}
 	log.Printf("Delete extract for datasource %s", datasourceId)
 	return nil
@@ -641,113 +680,52 @@ func GetDocumentNameFromPath(fullpath string) (string, string) {
 	return baseName[0 : len(baseName)-len(extension)], extension[1:]
 }
 
-func (tabl *TabGo) GetProjectID(projectName string) (string, error) {
-	projectID := ""
-
-	pageNum := 1    // default
-	pageSize := 100 // default
-
-	uri := fmt.Sprintf("%s/sites/%s/projects?pageSize=%d&pageNumber=%d", tabl.ApiURL(), tabl.CurrentSiteID, pageSize, pageNum)
-	req, err := http.NewRequest("GET", uri, nil)
-	if err != nil {
-		return projectID, errors.Wrapf(err, "can not post")
-	}
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("X-tableau-auth", tabl.CurrentToken)
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return projectID, errors.Wrapf(err, "can not client.Do(request) to get ProjectID from tableau")
+// GetProjectID returns the id of the project at projectPath ("Parent/Child/Grandchild", cfr SplitProjectPath),
+// every project along the path that does not exist yet is created.
+// The project hierarchy of the site is read once and cached for the rest of the session.
+func (tabl *TabGo) GetProjectID(projectPath string) (string, error) {
+	names := SplitProjectPath(projectPath)
+	if len(names) == 0 {
+		return "", fmt.Errorf("invalid project path '%s'", projectPath)
 	}
 
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return projectID, errors.Wrapf(err, "can not read response body")
-	}
-	projectsHolder := ProjectsHolder{}
-	err = json.NewDecoder(bytes.NewReader(body)).Decode(&projectsHolder)
+	roots, err := tabl.cachedProjectTree()
 	if err != nil {
-		return projectID, errors.Wrapf(err, "can not json decode")
+		return "", errors.Wrapf(err, "can not get projects")
 	}
 
-	projectPath := strings.SplitN(projectName, "/", -1)
-	var parentId string
-	for pathIndex, pathPart := range projectPath {
-		for _, project := range projectsHolder.Projects.Projects {
-			if project.Name == pathPart {
-				if project.ParentProjectId == "" {
-					if len(projectPath) == 1 {
-						return string(project.Id), nil
-					}
-					parentId = string(project.Id)
-					break
-				}
-				if parentId != "" && parentId == string(project.ParentProjectId) {
-					if pathIndex == len(projectPath)-1 {
-						// Yes, found it !
-						return string(project.Id), nil
-					}
-					// Since this is not the final match in the projectPath,
-					// we need to continue to descend..
-					// The new parent becomes this pathPart
-					parentId = string(project.Id)
-					break
-				}
+	var parent *ProjectNode
+	siblings := roots
+	for _, name := range names {
+		node := findProjectNode(siblings, name)
+		if node == nil {
+			parentID := ""
+			if parent != nil {
+				parentID = string(parent.Project.Id)
+			}
+			project, err := tabl.CreateProjectWithDetails(ProjectType{Name: name, ParentProjectId: ResourceIdType(parentID)})
+			if err != nil {
+				return "", errors.Wrapf(err, "can not create project %s (parentProject: %s)", name, parentID)
 			}
+			node = tabl.cacheProject(project)
 		}
+		parent = node
+		siblings = node.Children
 	}
 
-	// no project found,  so let's create it
-	projectID, err = tabl.CreateProject(parentId, projectPath[len(projectPath)-1])
-	if err != nil {
-		return projectID, errors.Wrapf(err, "can not create project %s (parentProject: %s)", projectPath, parentId)
-	}
-
-	return projectID, nil
+	return string(parent.Project.Id), nil
 }
 
+// CreateProject creates a project named projectName, an empty parentProjectID creates a top level project
 func (tabl *TabGo) CreateProject(parentProjectID, projectName string) (string, error) {
-	projectID := ""
-
-	uri := fmt.Sprintf("%s/sites/%s/projects", tabl.ApiURL(), tabl.CurrentSiteID)
-	payload := fmt.Sprintf(`<tsRequest>
-	<project
-      parentProjectId="%s"
-	  name="%s"
-	  description="!%s.png!" />
-</tsRequest>`, parentProjectID, projectName, projectName)
-	req, err := http.NewRequest("POST", uri, strings.NewReader(payload))
-	if err != nil {
-		return projectID, errors.Wrapf(err, "can not post")
-	}
-	//req.Header.Set("Accept", "text/xml")
-	req.Header.Set("Content-Type", "text/xml")
-	req.Header.Set("X-tableau-auth", tabl.CurrentToken)
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return projectID, errors.Wrapf(err, "can not client.Do(request) to create ProjectID from tableau")
-	}
-
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return projectID, errors.Wrapf(err, "can not read response body")
-	}
-
-	if strings.Contains(strings.ToLower(string(body)), "error") {
-		return projectID, fmt.Errorf("create project failed: %s", string(body))
-	}
-
-	var tsresponse TsResponse
-	err = xml.Unmarshal([]byte(body), &tsresponse)
+	project, err := tabl.CreateProjectWithDetails(ProjectType{
+		Name:            projectName,
+		ParentProjectId: ResourceIdType(parentProjectID),
+	})
 	if err != nil {
-		return projectID, errors.Wrapf(err, "can not xml unmarshall reponse '%s' ", body)
+		return "", err
 	}
-	projectID = string(tsresponse.Project.Id)
-	return projectID, nil
+	return string(project.Id), nil
 }
 
 func uploadFile(payloadFieldName, payloadContentType, payloadContent, fileFieldName, filePath, uri string, documentExtension string, tablToken string) (TsResponse, error) {
@@ -909,6 +887,49 @@ func Unzip(src, dest string) error {
 	return nil
 }
 
+// Zip writes the files of the directory src, and of its subdirectories, to the zip file dest,
+// named by their path relative to src, e.g. to repackage an unzipped twbx
+func Zip(src, dest string) error {
+	zipFile, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	defer zipFile.Close()
+
+	w := zip.NewWriter(zipFile)
+	err = filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		name, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		header, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(name)
+		header.Method = zip.Deflate
+		entry, err := w.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		_, err = io.Copy(entry, file)
+		return err
+	})
+	if err != nil {
+		w.Close()
+		return err
+	}
+	return w.Close()
+}
+
 func ReplaceAllStringSubmatchFunc(re *regexp.Regexp, str string, repl func([]string) string) string {
 	result := ""
 	lastIndex := 0
@@ -0,0 +1,106 @@
+package tableau
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testConnectionFinder finds target connections by caption
+type testConnectionFinder map[string]Connection
+
+func (cf testConnectionFinder) FindConnection(caption string) (Connection, error) {
+	if connection, found := cf[caption]; found {
+		return connection, nil
+	}
+	return Connection{}, fmt.Errorf("no target connection found for caption '%s'", caption)
+}
+
+const testWorkbook = `<?xml version='1.0' encoding='utf-8' ?>
+<workbook version='18.1'>
+  <datasources>
+    <datasource name='federated.1'>
+      <connection class='federated'>
+        <named-connections>
+          <named-connection caption='Local' name='excel.1'>
+            <connection class='excel-direct' filename='orders.xlsx' />
+          </named-connection>
+          <named-connection caption='Sales DWH' name='oracle.1'>
+            <connection class='oracle' schema='DEV' server='dwh-dev' username='dev_reader' />
+          </named-connection>
+        </named-connections>
+        <relation connection='oracle.1' name='ORDERS' table='[DEV].[ORDERS]' type='table' />
+      </connection>
+    </datasource>
+  </datasources>
+  <worksheets>
+    <worksheet name='Sheet 1'><repository-location id='Sheet1' site='dev' /></worksheet>
+  </worksheets>
+</workbook>
+`
+
+func TestRewriteWorkbookFile(t *testing.T) {
+	finder := testConnectionFinder{
+		"Local":     {},
+		"Sales DWH": {ServerAddress: "dwh-prod", UserName: "prod_reader", Schema: "PROD"},
+	}
+	rewritten := strings.NewReplacer(
+		"schema='DEV' server='dwh-dev' username='dev_reader'", "schema='PROD' server='dwh-prod' username='prod_reader'",
+		"[DEV].[ORDERS]", "[PROD].[ORDERS]",
+		"site='dev'", "site='prod'",
+	).Replace(testWorkbook)
+
+	tests := []struct {
+		name     string
+		workbook string
+		finder   ConnectionFinder
+		expected string
+		// problem is part of the expected error, "" when the workbook can be rewritten
+		problem string
+	}{
+		{"rewrite", testWorkbook, finder, rewritten, ""},
+		{"without named connections", "<workbook><worksheets><worksheet name='Sheet 1' /></worksheets></workbook>", finder,
+			"<workbook><worksheets><worksheet name='Sheet 1' /></worksheets></workbook>", ""},
+		{"missing target connection", testWorkbook, testConnectionFinder{"Local": {}}, "", "can not find targetConnection for caption 'Sales DWH'"},
+		{"double quoted named connection", strings.Replace(testWorkbook, "name='oracle.1'", `name="oracle.1"`, 1), finder, "",
+			"no named connection 'oracle.1' found"},
+		{"invalid xml", "<workbook>", finder, "", "can not xml.Unmarshall"},
+	}
+
+	dir, err := ioutil.TempDir("", "tabgo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	documentPath, targetPath := filepath.Join(dir, "Sales.twb"), filepath.Join(dir, "rewritten.twb")
+	tabl := &TabGo{CurrentSiteName: "prod"}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := ioutil.WriteFile(documentPath, []byte(test.workbook), 0644); err != nil {
+				t.Fatal(err)
+			}
+			err := tabl.rewriteWorkbookFile(documentPath, targetPath, test.finder)
+			switch {
+			case test.problem == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case test.problem != "" && err == nil:
+				t.Fatalf("expecting error '%s'", test.problem)
+			case test.problem != "" && !strings.Contains(err.Error(), test.problem):
+				t.Fatalf("error '%v' does not contain '%s'", err, test.problem)
+			case test.problem != "":
+				return
+			}
+			content, err := ioutil.ReadFile(targetPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(content) != test.expected {
+				t.Errorf("rewritten workbook:\n%s\nexpecting:\n%s", content, test.expected)
+			}
+		})
+	}
+}
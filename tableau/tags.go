@@ -0,0 +1,225 @@
+package tableau
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// AddTags adds tags to a workbook, datasource, view or flow and returns all of its tags
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_workbooksviews.htm#add_tags_to_workbook
+func (tabl *TabGo) AddTags(kind ContentKind, id string, labels []string) ([]TagType, error) {
+	tags := ""
+	for _, label := range labels {
+		tags += fmt.Sprintf(`<tag label="%s" />`, xmlEscape(label))
+	}
+	tsResponse, err := tabl.doTsRequest("PUT", fmt.Sprintf("%s/%s/%s/tags", tabl.SiteURL(), kind, id),
+		fmt.Sprintf(`<tsRequest><tags>%s</tags></tsRequest>`, tags))
+	if err != nil {
+		return tsResponse.Tags.Tag, errors.Wrapf(err, "can not add tags to %s '%s'", kind.element(), id)
+	}
+	return tsResponse.Tags.Tag, nil
+}
+
+// DeleteTag deletes a tag from a workbook, datasource, view or flow
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_workbooksviews.htm#delete_tag_from_workbook
+func (tabl *TabGo) DeleteTag(kind ContentKind, id string, label string) error {
+	_, err := tabl.doRequest("DELETE", fmt.Sprintf("%s/%s/%s/tags/%s", tabl.SiteURL(), kind, id, url.PathEscape(label)), "")
+	if err != nil {
+		return errors.Wrapf(err, "can not delete tag '%s' from %s '%s'", label, kind.element(), id)
+	}
+	return nil
+}
+
+// ListTaggedContent returns the workbooks, datasources, views and flows of the current site tagged with label
+func (tabl *TabGo) ListTaggedContent(label string) ([]Content, error) {
+	tagged := []Content{}
+	for _, kind := range ContentKinds {
+		contents, err := tabl.ListContent(kind, "tags:eq:"+label)
+		if err != nil {
+			return tagged, err
+		}
+		tagged = append(tagged, contents...)
+	}
+	return tagged, nil
+}
+
+// tagLabels returns the labels of tags
+func tagLabels(tags TagListType) []string {
+	labels := []string{}
+	for _, tag := range tags.Tag {
+		labels = append(labels, tag.Label)
+	}
+	return labels
+}
+
+// hasTag tells whether labels contains label, tableau compares tags ignoring case
+func hasTag(labels []string, label string) bool {
+	for _, l := range labels {
+		if strings.EqualFold(l, label) {
+			return true
+		}
+	}
+	return false
+}
+
+// TagRule adds and removes tags on the content whose path matches a glob pattern (cfr path.Match, "*" does not match "/").
+// An empty Kind matches workbooks, datasources, views and flows.
+type TagRule struct {
+	Content string      `yaml:"content"`
+	Kind    ContentKind `yaml:"kind,omitempty"`
+	Add     []string    `yaml:"add,omitempty"`
+	Remove  []string    `yaml:"remove,omitempty"`
+}
+
+// TagManifest is the tagging of the content of a site, its rules apply in order
+// Example yaml:
+//
+//	rules:
+//	  - content: Finance/*/*
+//	    kind: workbooks
+//	    add: [finance]
+//	  - content: Finance/Archive/*
+//	    add: [archived]
+//	    remove: [certified]
+type TagManifest struct {
+	Rules []TagRule `yaml:"rules"`
+}
+
+// ReadTagManifest reads a tag manifest from a yaml file
+func ReadTagManifest(path string) (TagManifest, error) {
+	manifest := TagManifest{}
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return manifest, errors.Wrapf(err, "can not read %s", path)
+	}
+	err = yaml.UnmarshalStrict(content, &manifest)
+	if err != nil {
+		return manifest, errors.Wrapf(err, "can not yaml decode %s", path)
+	}
+	if err = manifest.Validate(); err != nil {
+		return manifest, errors.Wrapf(err, "invalid tag manifest %s", path)
+	}
+	return manifest, nil
+}
+
+// Validate checks the patterns, kinds and tags of the rules of a tag manifest
+func (manifest TagManifest) Validate() error {
+	problems := []string{}
+	for i, rule := range manifest.Rules {
+		if _, err := path.Match(rule.Content, ""); err != nil || rule.Content == "" {
+			problems = append(problems, fmt.Sprintf("rule %d: invalid content pattern '%s'", i+1, rule.Content))
+		}
+		if rule.Kind != "" {
+			if _, err := ParseContentKind(string(rule.Kind)); err != nil {
+				problems = append(problems, fmt.Sprintf("rule %d: %v", i+1, err))
+			}
+		}
+		for _, label := range append(append([]string{}, rule.Add...), rule.Remove...) {
+			if strings.TrimSpace(label) == "" || strings.Contains(label, ",") {
+				problems = append(problems, fmt.Sprintf("rule %d: invalid tag '%s', expecting a non empty label without commas", i+1, label))
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// TagChangeAction is what a TagChange does on the server
+type TagChangeAction string
+
+const (
+	TagAdd    TagChangeAction = "add"
+	TagRemove TagChangeAction = "remove"
+)
+
+// TagChange adds or removes a single tag of a workbook, datasource, view or flow
+type TagChange struct {
+	Action  TagChangeAction
+	Content Content
+	Label   string
+}
+
+func (change TagChange) String() string {
+	symbol := map[TagChangeAction]string{TagAdd: "+", TagRemove: "-"}[change.Action]
+	return fmt.Sprintf("%s %s: %s tag %s", symbol, change.Content, change.Action, change.Label)
+}
+
+// PlanTags returns the tag changes that make the content of the current site match the rules of a manifest.
+// Tags the content already has are not added again, tags it does not have are not removed.
+func (tabl *TabGo) PlanTags(manifest TagManifest) ([]TagChange, error) {
+	changes := []TagChange{}
+	contents := make(map[ContentKind][]Content)
+	// the tags of the content once the earlier changes are applied
+	tags := make(map[ResourceIdType][]string)
+
+	for _, rule := range manifest.Rules {
+		kinds := ContentKinds
+		if rule.Kind != "" {
+			kind, err := ParseContentKind(string(rule.Kind))
+			if err != nil {
+				return changes, err
+			}
+			kinds = []ContentKind{kind}
+		}
+		for _, kind := range kinds {
+			if _, listed := contents[kind]; !listed {
+				listing, err := tabl.ListContent(kind, "")
+				if err != nil {
+					return changes, err
+				}
+				contents[kind] = listing
+				for _, content := range listing {
+					tags[content.Id] = content.Tags
+				}
+			}
+			for _, content := range contents[kind] {
+				if matched, _ := path.Match(rule.Content, content.Path); !matched {
+					continue
+				}
+				for _, label := range rule.Add {
+					if !hasTag(tags[content.Id], label) {
+						changes = append(changes, TagChange{Action: TagAdd, Content: content, Label: label})
+						tags[content.Id] = append(tags[content.Id], label)
+					}
+				}
+				for _, label := range rule.Remove {
+					if hasTag(tags[content.Id], label) {
+						changes = append(changes, TagChange{Action: TagRemove, Content: content, Label: label})
+						remaining := []string{}
+						for _, tag := range tags[content.Id] {
+							if !strings.EqualFold(tag, label) {
+								remaining = append(remaining, tag)
+							}
+						}
+						tags[content.Id] = remaining
+					}
+				}
+			}
+		}
+	}
+	return changes, nil
+}
+
+// ApplyTags executes tag changes in order
+func (tabl *TabGo) ApplyTags(changes []TagChange) error {
+	for _, change := range changes {
+		var err error
+		if change.Action == TagAdd {
+			_, err = tabl.AddTags(change.Content.Kind, string(change.Content.Id), []string{change.Label})
+		} else {
+			err = tabl.DeleteTag(change.Content.Kind, string(change.Content.Id), change.Label)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "can not apply '%s'", change)
+		}
+	}
+	return nil
+}
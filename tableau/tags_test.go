@@ -0,0 +1,130 @@
+package tableau
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTagManifestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		rule TagRule
+		// problem is part of the expected error, "" when the rule is valid
+		problem string
+	}{
+		{"valid", TagRule{Content: "Finance/*/*", Kind: "workbooks", Add: []string{"finance"}, Remove: []string{"draft"}}, ""},
+		{"singular kind", TagRule{Content: "*", Kind: "View", Add: []string{"finance"}}, ""},
+		{"empty pattern", TagRule{Add: []string{"finance"}}, "invalid content pattern ''"},
+		{"invalid pattern", TagRule{Content: "Finance/[", Add: []string{"finance"}}, "invalid content pattern 'Finance/['"},
+		{"unknown kind", TagRule{Content: "*", Kind: "databases", Add: []string{"finance"}}, "unknown content kind 'databases'"},
+		{"empty tag", TagRule{Content: "*", Add: []string{""}}, "invalid tag ''"},
+		{"tag with comma", TagRule{Content: "*", Remove: []string{"a,b"}}, "invalid tag 'a,b'"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := TagManifest{Rules: []TagRule{test.rule}}.Validate()
+			switch {
+			case test.problem == "" && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case test.problem != "" && err == nil:
+				t.Errorf("expecting error '%s'", test.problem)
+			case test.problem != "" && !strings.Contains(err.Error(), "rule 1: "+test.problem):
+				t.Errorf("error '%v' does not contain 'rule 1: %s'", err, test.problem)
+			}
+		})
+	}
+}
+
+func TestPlanTags(t *testing.T) {
+	tabl, cleanup := newTestServer(t, map[string]string{
+		"/projects": `<projects>
+			<project id="finance" name="Finance" />
+			<project id="archive" name="Archive" parentProjectId="finance" />
+			<project id="hr" name="HR" />
+		</projects>`,
+		"/workbooks": `<workbooks>
+			<workbook id="revenue" name="Revenue"><project id="finance" /><tags><tag label="Finance" /></tags></workbook>
+			<workbook id="costs" name="Costs"><project id="archive" /><tags><tag label="draft" /></tags></workbook>
+			<workbook id="salaries" name="Salaries"><project id="hr" /></workbook>
+		</workbooks>`,
+		"/datasources": `<datasources>
+			<datasource id="ledger" name="Ledger"><project id="finance" /></datasource>
+		</datasources>`,
+		"/views": `<views>
+			<view id="overview" name="Overview"><workbook id="revenue" /></view>
+		</views>`,
+	})
+	defer cleanup()
+
+	tests := []struct {
+		name    string
+		rules   []TagRule
+		changes []string
+	}{
+		{
+			name:    "existing tags are not added again, ignoring case",
+			rules:   []TagRule{{Content: "Finance/*", Kind: KindWorkbook, Add: []string{"finance"}}},
+			changes: []string{},
+		},
+		{
+			name:  "pattern and kind select the content",
+			rules: []TagRule{{Content: "Finance/*", Add: []string{"reviewed"}}},
+			changes: []string{
+				"+ workbook Finance/Revenue: add tag reviewed",
+				"+ datasource Finance/Ledger: add tag reviewed",
+			},
+		},
+		{
+			name:  "star does not match slash",
+			rules: []TagRule{{Content: "Finance/*/*", Kind: KindWorkbook, Add: []string{"archived"}}},
+			changes: []string{
+				"+ workbook Finance/Archive/Costs: add tag archived",
+			},
+		},
+		{
+			name:    "missing tags are not removed",
+			rules:   []TagRule{{Content: "*/*", Remove: []string{"draft"}}},
+			changes: []string{},
+		},
+		{
+			name: "rules apply in order",
+			rules: []TagRule{
+				{Content: "Finance/*/*", Kind: KindWorkbook, Add: []string{"draft", "archived"}},
+				{Content: "Finance/Archive/Costs", Kind: KindWorkbook, Add: []string{"archived"}, Remove: []string{"draft"}},
+				{Content: "Finance/Revenue/*", Kind: KindView, Add: []string{"kpi"}},
+			},
+			changes: []string{
+				"+ workbook Finance/Archive/Costs: add tag archived",
+				"- workbook Finance/Archive/Costs: remove tag draft",
+				"+ view Finance/Revenue/Overview: add tag kpi",
+			},
+		},
+		{
+			name: "a tag removed by an earlier rule is added again",
+			rules: []TagRule{
+				{Content: "Finance/Archive/*", Kind: KindWorkbook, Remove: []string{"draft"}},
+				{Content: "Finance/Archive/*", Kind: KindWorkbook, Add: []string{"draft"}},
+			},
+			changes: []string{
+				"- workbook Finance/Archive/Costs: remove tag draft",
+				"+ workbook Finance/Archive/Costs: add tag draft",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			changes, err := tabl.PlanTags(TagManifest{Rules: test.rules})
+			if err != nil {
+				t.Fatal(err)
+			}
+			lines := []string{}
+			for _, change := range changes {
+				lines = append(lines, change.String())
+			}
+			if !reflect.DeepEqual(lines, test.changes) {
+				t.Errorf("plan:\n%q\nexpecting:\n%q", lines, test.changes)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package tableau
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ListUsers returns all users of the current site matching the (optional) filter expression
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_users_and_groups.htm#get_users_on_site
+func (tabl *TabGo) ListUsers(filter string) ([]UserType, error) {
+	users := []UserType{}
+	err := tabl.forEachPage(tabl.listURI("users", filter), func(tsResponse TsResponse) int {
+		users = append(users, tsResponse.Users.User...)
+		return len(tsResponse.Users.User)
+	})
+	if err != nil {
+		return users, errors.Wrapf(err, "can not list users")
+	}
+	return users, nil
+}
+
+// GetUserByName returns the user of the current site with the given (login) name,
+// like tableau the name is not case sensitive
+func (tabl *TabGo) GetUserByName(name string) (UserType, error) {
+	users, err := tabl.ListUsers("name:eq:" + name)
+	if err != nil {
+		return UserType{}, err
+	}
+	if user, found := findUser(users, name); found {
+		return user, nil
+	}
+	return UserType{}, fmt.Errorf("no user '%s' found on site '%s'", name, tabl.CurrentSiteName)
+}
+
+// findUser returns the user of users with the given (login) name, ignoring case
+func findUser(users []UserType, name string) (UserType, bool) {
+	for _, user := range users {
+		if strings.EqualFold(user.Name, name) {
+			return user, true
+		}
+	}
+	return UserType{}, false
+}
+
+// QueryUser returns the details of a user of the current site
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_users_and_groups.htm#query_user_on_site
+func (tabl *TabGo) QueryUser(userID string) (UserType, error) {
+	tsResponse, err := tabl.doTsRequest("GET", fmt.Sprintf("%s/users/%s", tabl.SiteURL(), userID), "")
+	if err != nil {
+		return tsResponse.User, errors.Wrapf(err, "can not query user '%s'", userID)
+	}
+	return tsResponse.User, nil
+}
+
+// AddUser adds a user with the given site role and (optional) authentication setting to the current site
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_users_and_groups.htm#add_user_to_site
+func (tabl *TabGo) AddUser(name string, siteRole SiteRoleType, authSetting SiteUserAuthSettingType) (UserType, error) {
+	attributes := fmt.Sprintf(`name="%s" siteRole="%s"`, xmlEscape(name), siteRole)
+	if authSetting != "" {
+		attributes += fmt.Sprintf(` authSetting="%s"`, authSetting)
+	}
+	tsResponse, err := tabl.doTsRequest("POST", fmt.Sprintf("%s/users", tabl.SiteURL()),
+		fmt.Sprintf(`<tsRequest><user %s /></tsRequest>`, attributes))
+	if err != nil {
+		return tsResponse.User, errors.Wrapf(err, "can not add user '%s'", name)
+	}
+	return tsResponse.User, nil
+}
+
+// UpdateUser changes the full name, email, password, site role and/or authentication setting of a user,
+// empty values of update are left unchanged on the server.
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_users_and_groups.htm#update_user
+func (tabl *TabGo) UpdateUser(userID string, update UserType) (UserType, error) {
+	attributes := ""
+	if update.FullName != "" {
+		attributes += fmt.Sprintf(` fullName="%s"`, xmlEscape(update.FullName))
+	}
+	if update.Email != "" {
+		attributes += fmt.Sprintf(` email="%s"`, xmlEscape(update.Email))
+	}
+	if update.Password != "" {
+		attributes += fmt.Sprintf(` password="%s"`, xmlEscape(update.Password))
+	}
+	if update.SiteRole != "" {
+		attributes += fmt.Sprintf(` siteRole="%s"`, update.SiteRole)
+	}
+	if update.AuthSetting != "" {
+		attributes += fmt.Sprintf(` authSetting="%s"`, update.AuthSetting)
+	}
+	tsResponse, err := tabl.doTsRequest("PUT", fmt.Sprintf("%s/users/%s", tabl.SiteURL(), userID),
+		fmt.Sprintf(`<tsRequest><user%s /></tsRequest>`, attributes))
+	if err != nil {
+		return tsResponse.User, errors.Wrapf(err, "can not update user '%s'", userID)
+	}
+	return tsResponse.User, nil
+}
+
+// UpdateUserSiteRole changes the site role of a user
+func (tabl *TabGo) UpdateUserSiteRole(userID string, siteRole SiteRoleType) (UserType, error) {
+	return tabl.UpdateUser(userID, UserType{SiteRole: siteRole})
+}
+
+// UpdateUserAuthSetting changes the authentication setting (ServerDefault or SAML) of a user
+func (tabl *TabGo) UpdateUserAuthSetting(userID string, authSetting SiteUserAuthSettingType) (UserType, error) {
+	return tabl.UpdateUser(userID, UserType{AuthSetting: authSetting})
+}
+
+// RemoveUser removes a user from the current site, a user that still owns content can not be removed
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_users_and_groups.htm#remove_user_from_site
+func (tabl *TabGo) RemoveUser(userID string) error {
+	_, err := tabl.doRequest("DELETE", fmt.Sprintf("%s/users/%s", tabl.SiteURL(), userID), "")
+	if err != nil {
+		return errors.Wrapf(err, "can not remove user '%s'", userID)
+	}
+	return nil
+}
@@ -0,0 +1,215 @@
+package tableau
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// userImportColumns are the columns a user import csv may have, name and siteRole are required
+var userImportColumns = []string{"name", "fullName", "email", "siteRole", "authSetting", "password"}
+
+// UserImportRow is a single row of a user import csv
+type UserImportRow struct {
+	Line int
+	User UserType
+}
+
+// ReadUserImportCSV reads the users to import from a csv file.
+// The first line is a header naming the columns, cfr userImportColumns, e.g.
+//
+//	name,fullName,email,siteRole
+//	jdoe,John Doe,jdoe@example.com,Explorer
+func ReadUserImportCSV(path string) ([]UserImportRow, error) {
+	rows := []UserImportRow{}
+	file, err := os.Open(path)
+	if err != nil {
+		return rows, errors.Wrapf(err, "can not open %s", path)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return rows, errors.Wrapf(err, "can not read header of %s", path)
+	}
+	columns := make(map[string]int)
+	for i, column := range header {
+		known := false
+		for _, importColumn := range userImportColumns {
+			if strings.EqualFold(strings.TrimSpace(column), importColumn) {
+				columns[importColumn] = i
+				known = true
+			}
+		}
+		if !known {
+			return rows, fmt.Errorf("unknown column '%s' in %s, expecting some of %s", column, path, strings.Join(userImportColumns, ", "))
+		}
+	}
+	for _, required := range []string{"name", "siteRole"} {
+		if _, found := columns[required]; !found {
+			return rows, fmt.Errorf("missing column '%s' in %s", required, path)
+		}
+	}
+
+	value := func(record []string, column string) string {
+		if i, found := columns[column]; found && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return rows, errors.Wrapf(err, "can not read line %d of %s", line, path)
+		}
+		rows = append(rows, UserImportRow{
+			Line: line,
+			User: UserType{
+				Name:        value(record, "name"),
+				FullName:    value(record, "fullName"),
+				Email:       value(record, "email"),
+				SiteRole:    SiteRoleType(value(record, "siteRole")),
+				AuthSetting: SiteUserAuthSettingType(value(record, "authSetting")),
+				Password:    value(record, "password"),
+			},
+		})
+	}
+	return rows, nil
+}
+
+// UserImportAction is what ImportUsers did for a row
+type UserImportAction string
+
+const (
+	UserCreated   UserImportAction = "created"
+	UserUpdated   UserImportAction = "updated"
+	UserUnchanged UserImportAction = "unchanged"
+	UserFailed    UserImportAction = "failed"
+)
+
+// UserImportResult is the outcome of importing a single row
+type UserImportResult struct {
+	Row     UserImportRow
+	Action  UserImportAction
+	UserID  ResourceIdType
+	Details []string
+	Err     error
+}
+
+func (result UserImportResult) String() string {
+	line := fmt.Sprintf("line %d: %s %s", result.Row.Line, result.Row.User.Name, result.Action)
+	if len(result.Details) > 0 {
+		line += " (" + strings.Join(result.Details, ", ") + ")"
+	}
+	if result.Err != nil {
+		line += fmt.Sprintf(": %v", result.Err)
+	}
+	return line
+}
+
+// ImportUsers creates the users of rows that do not exist on the current site yet
+// and updates the full name, email, site role and authentication setting of the ones that do.
+// Passwords are only set for new users, so importing the same rows twice changes nothing the second time.
+// A new user whose details can not be set is removed again, so a rerun creates it from scratch.
+// A failing row does not stop the import, its error is part of its result.
+func (tabl *TabGo) ImportUsers(rows []UserImportRow) []UserImportResult {
+	results := []UserImportResult{}
+	for _, row := range rows {
+		result := tabl.importUser(row)
+		if result.Err != nil {
+			result.Action = UserFailed
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+func (tabl *TabGo) importUser(row UserImportRow) UserImportResult {
+	result := UserImportResult{Row: row}
+	desired := row.User
+	if desired.Name == "" || desired.SiteRole == "" {
+		result.Err = fmt.Errorf("name and siteRole are required")
+		return result
+	}
+
+	users, err := tabl.ListUsers("name:eq:" + desired.Name)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	existing, found := findUser(users, desired.Name)
+
+	if !found {
+		user, err := tabl.AddUser(desired.Name, desired.SiteRole, desired.AuthSetting)
+		if err != nil {
+			result.Err = err
+			return result
+		}
+		result.Action = UserCreated
+		result.UserID = user.Id
+		details := UserType{FullName: desired.FullName, Email: desired.Email, Password: desired.Password}
+		if details != (UserType{}) {
+			if _, err = tabl.UpdateUser(string(user.Id), details); err != nil {
+				result.Err = err
+				if removeErr := tabl.RemoveUser(string(user.Id)); removeErr != nil {
+					result.Err = errors.Wrapf(err, "can not remove the incomplete user (%v)", removeErr)
+				} else {
+					result.UserID = ""
+				}
+			}
+		}
+		return result
+	}
+
+	result.UserID = existing.Id
+	current, err := tabl.QueryUser(string(existing.Id))
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	update, details := userUpdate(current, desired)
+	result.Details = details
+	if update == (UserType{}) {
+		result.Action = UserUnchanged
+		return result
+	}
+	if _, err = tabl.UpdateUser(string(existing.Id), update); err != nil {
+		result.Err = err
+		return result
+	}
+	result.Action = UserUpdated
+	return result
+}
+
+// userUpdate returns the update that makes current look like desired and a description of each change,
+// the full name, email and authentication setting are only changed when desired has one
+func userUpdate(current UserType, desired UserType) (UserType, []string) {
+	update := UserType{}
+	details := []string{}
+	if desired.FullName != "" && desired.FullName != current.FullName {
+		update.FullName = desired.FullName
+		details = append(details, fmt.Sprintf("fullName: '%s' -> '%s'", current.FullName, desired.FullName))
+	}
+	if desired.Email != "" && desired.Email != current.Email {
+		update.Email = desired.Email
+		details = append(details, fmt.Sprintf("email: '%s' -> '%s'", current.Email, desired.Email))
+	}
+	if desired.SiteRole != current.SiteRole {
+		update.SiteRole = desired.SiteRole
+		details = append(details, fmt.Sprintf("siteRole: %s -> %s", current.SiteRole, desired.SiteRole))
+	}
+	if desired.AuthSetting != "" && desired.AuthSetting != current.AuthSetting {
+		update.AuthSetting = desired.AuthSetting
+		details = append(details, fmt.Sprintf("authSetting: %s -> %s", current.AuthSetting, desired.AuthSetting))
+	}
+	return update, details
+}
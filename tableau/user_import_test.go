@@ -0,0 +1,104 @@
+package tableau
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadUserImportCSV(t *testing.T) {
+	tests := []struct {
+		name  string
+		csv   string
+		rows  []UserImportRow
+		error string
+	}{
+		{
+			name: "columns in any order and case",
+			csv:  "SiteRole, name,email\nExplorer, jdoe ,jdoe@example.com\nViewer,asmith,\n",
+			rows: []UserImportRow{
+				{Line: 2, User: UserType{Name: "jdoe", Email: "jdoe@example.com", SiteRole: "Explorer"}},
+				{Line: 3, User: UserType{Name: "asmith", SiteRole: "Viewer"}},
+			},
+		},
+		{
+			name: "all columns",
+			csv:  "name,fullName,email,siteRole,authSetting,password\njdoe,\"Doe, John\",jdoe@example.com,Creator,SAML,secret\n",
+			rows: []UserImportRow{
+				{Line: 2, User: UserType{Name: "jdoe", FullName: "Doe, John", Email: "jdoe@example.com", SiteRole: "Creator", AuthSetting: "SAML", Password: "secret"}},
+			},
+		},
+		{name: "header only", csv: "name,siteRole\n", rows: []UserImportRow{}},
+		{name: "unknown column", csv: "name,siteRole,role\n", error: "unknown column 'role'"},
+		{name: "missing site role", csv: "name,email\n", error: "missing column 'siteRole'"},
+		{name: "empty file", csv: "", error: "can not read header"},
+	}
+	dir, err := ioutil.TempDir("", "tabgo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for i, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(dir, string(rune('a'+i))+".csv")
+			if err := ioutil.WriteFile(path, []byte(test.csv), 0644); err != nil {
+				t.Fatal(err)
+			}
+			rows, err := ReadUserImportCSV(path)
+			if test.error != "" {
+				if err == nil || !strings.Contains(err.Error(), test.error) {
+					t.Fatalf("error '%v' does not contain '%s'", err, test.error)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(rows, test.rows) {
+				t.Errorf("rows = %+v, expecting %+v", rows, test.rows)
+			}
+		})
+	}
+}
+
+func TestUserUpdate(t *testing.T) {
+	current := UserType{Name: "jdoe", FullName: "John Doe", Email: "jdoe@example.com", SiteRole: "Explorer", AuthSetting: "ServerDefault"}
+
+	tests := []struct {
+		name    string
+		desired UserType
+		update  UserType
+		details []string
+	}{
+		{"same", UserType{Name: "jdoe", FullName: "John Doe", Email: "jdoe@example.com", SiteRole: "Explorer", AuthSetting: "ServerDefault"},
+			UserType{}, []string{}},
+		{"empty values are kept", UserType{Name: "jdoe", SiteRole: "Explorer"}, UserType{}, []string{}},
+		{"password of an existing user is not changed", UserType{Name: "jdoe", SiteRole: "Explorer", Password: "secret"}, UserType{}, []string{}},
+		{"site role", UserType{Name: "jdoe", SiteRole: "Viewer"}, UserType{SiteRole: "Viewer"}, []string{"siteRole: Explorer -> Viewer"}},
+		{"details", UserType{Name: "jdoe", FullName: "J. Doe", Email: "john@example.com", SiteRole: "Explorer", AuthSetting: "SAML"},
+			UserType{FullName: "J. Doe", Email: "john@example.com", AuthSetting: "SAML"},
+			[]string{"fullName: 'John Doe' -> 'J. Doe'", "email: 'jdoe@example.com' -> 'john@example.com'", "authSetting: ServerDefault -> SAML"}},
+	}
+	for _, test := range tests {
+		update, details := userUpdate(current, test.desired)
+		if update != test.update {
+			t.Errorf("%s: update = %+v, expecting %+v", test.name, update, test.update)
+		}
+		if !reflect.DeepEqual(details, test.details) {
+			t.Errorf("%s: details = %q, expecting %q", test.name, details, test.details)
+		}
+	}
+}
+
+func TestFindUser(t *testing.T) {
+	users := []UserType{{Id: "u1", Name: "jdoe2"}, {Id: "u2", Name: "JDoe"}}
+	for name, id := range map[string]ResourceIdType{"jdoe": "u2", "JDOE": "u2", "jdoe2": "u1", "doe": ""} {
+		user, found := findUser(users, name)
+		if found != (id != "") || user.Id != id {
+			t.Errorf("findUser(%q) = %s, %t, expecting %s", name, user.Id, found, id)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package tableau
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ExportFormat is the kind of file a view is exported to
+type ExportFormat string
+
+// export formats
+const (
+	ExportPNG ExportFormat = "png"
+	ExportPDF ExportFormat = "pdf"
+	ExportCSV ExportFormat = "csv"
+)
+
+// ExportOptions are the options of a view or workbook export, options that do not apply to a format are ignored
+type ExportOptions struct {
+	// Filters filter the exported view, field name -> value (values separated by commas), e.g. Region -> West,East
+	Filters map[string]string
+	// MaxAge is the maximum age in minutes of a cached rendering, 0 leaves it to tableau
+	MaxAge int
+	// HighResolution renders png images in high resolution
+	HighResolution bool
+	// PageType (e.g. A4, Letter) and Orientation (Portrait or Landscape) are the page layout of a pdf
+	PageType    string
+	Orientation string
+}
+
+// query returns the query string of the options for an export to format
+func (options ExportOptions) query(format ExportFormat) string {
+	values := url.Values{}
+	for field, value := range options.Filters {
+		values.Set("vf_"+field, value)
+	}
+	if options.MaxAge > 0 {
+		values.Set("maxAge", fmt.Sprintf("%d", options.MaxAge))
+	}
+	switch format {
+	case ExportPNG:
+		if options.HighResolution {
+			values.Set("resolution", "high")
+		}
+	case ExportPDF:
+		if options.PageType != "" {
+			values.Set("type", options.PageType)
+		}
+		if options.Orientation != "" {
+			values.Set("orientation", options.Orientation)
+		}
+	}
+	if len(values) == 0 {
+		return ""
+	}
+	// tableau wants spaces in field names encoded as %20, a + in a value is already encoded as %2B
+	return "?" + strings.Replace(values.Encode(), "+", "%20", -1)
+}
+
+// ListViews returns all views on the current site matching the (optional) filter expression
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_workbooksviews.htm#query_views_for_site
+func (tabl *TabGo) ListViews(filter string) ([]ViewType, error) {
+	views := []ViewType{}
+	err := tabl.forEachPage(tabl.listURI("views", filter), func(tsResponse TsResponse) int {
+		views = append(views, tsResponse.Views.View...)
+		return len(tsResponse.Views.View)
+	})
+	if err != nil {
+		return views, errors.Wrapf(err, "can not list views")
+	}
+	return views, nil
+}
+
+// ListWorkbookViews returns the views of a workbook
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_workbooksviews.htm#query_views_for_workbook
+func (tabl *TabGo) ListWorkbookViews(workbookID string) ([]ViewType, error) {
+	tsResponse, err := tabl.doTsRequest("GET", fmt.Sprintf("%s/workbooks/%s/views", tabl.SiteURL(), workbookID), "")
+	if err != nil {
+		return tsResponse.Views.View, errors.Wrapf(err, "can not list views of workbook '%s'", workbookID)
+	}
+	return tsResponse.Views.View, nil
+}
+
+// FindView returns the view at contentPath, the path of its workbook followed by its name (e.g. "Finance/Reports/Sales/Overview")
+func (tabl *TabGo) FindView(contentPath string) (ViewType, error) {
+	names := SplitProjectPath(contentPath)
+	if len(names) < 3 {
+		return ViewType{}, fmt.Errorf("invalid view path '%s', expecting <project path>/<workbook>/<view>", contentPath)
+	}
+	workbook, err := tabl.FindWorkbook(JoinProjectPath(names[:len(names)-1]...))
+	if err != nil {
+		return ViewType{}, err
+	}
+	views, err := tabl.ListWorkbookViews(string(workbook.Id))
+	if err != nil {
+		return ViewType{}, err
+	}
+	for _, view := range views {
+		if view.Name == names[len(names)-1] {
+			return view, nil
+		}
+	}
+	return ViewType{}, fmt.Errorf("no view '%s' found on site '%s'", contentPath, tabl.CurrentSiteName)
+}
+
+// ExportView renders a view as png image, pdf or csv data and returns the content
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_workbooksviews.htm#query_view_image
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_workbooksviews.htm#query_view_pdf
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_workbooksviews.htm#query_view_data
+func (tabl *TabGo) ExportView(viewID string, format ExportFormat, options ExportOptions) ([]byte, error) {
+	resource := map[ExportFormat]string{ExportPNG: "image", ExportPDF: "pdf", ExportCSV: "data"}[format]
+	if resource == "" {
+		return nil, fmt.Errorf("invalid export format '%s', expecting %s, %s or %s", format, ExportPNG, ExportPDF, ExportCSV)
+	}
+	content, err := tabl.doRequest("GET", fmt.Sprintf("%s/views/%s/%s%s", tabl.SiteURL(), viewID, resource, options.query(format)), "")
+	if err != nil {
+		return nil, errors.Wrapf(err, "can not export view '%s' to %s", viewID, format)
+	}
+	return content, nil
+}
+
+// ExportWorkbookPDF renders all sheets of a workbook into a single pdf and returns its content
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_workbooksviews.htm#download_workbook_pdf
+func (tabl *TabGo) ExportWorkbookPDF(workbookID string, options ExportOptions) ([]byte, error) {
+	content, err := tabl.doRequest("GET", fmt.Sprintf("%s/workbooks/%s/pdf%s", tabl.SiteURL(), workbookID, options.query(ExportPDF)), "")
+	if err != nil {
+		return nil, errors.Wrapf(err, "can not export workbook '%s' to pdf", workbookID)
+	}
+	return content, nil
+}
+
+// WriteExport writes exported content to dir/<name>.<format> and returns the path of the file
+func WriteExport(content []byte, name string, format ExportFormat, dir string) (string, error) {
+	path := filepath.Join(dir, safeFileName(name)+"."+string(format))
+	if err := ioutil.WriteFile(path, content, 0644); err != nil {
+		return path, errors.Wrapf(err, "can not write %s", path)
+	}
+	return path, nil
+}
@@ -0,0 +1,29 @@
+package tableau
+
+import "testing"
+
+func TestExportOptionsQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		options ExportOptions
+		format  ExportFormat
+		query   string
+	}{
+		{"no options", ExportOptions{}, ExportPNG, ""},
+		{"max age", ExportOptions{MaxAge: 5}, ExportCSV, "?maxAge=5"},
+		{"filters are sorted", ExportOptions{Filters: map[string]string{"Region": "West,East", "Year": "2020"}}, ExportCSV,
+			"?vf_Region=West%2CEast&vf_Year=2020"},
+		{"spaces and plus signs", ExportOptions{Filters: map[string]string{"Sub Category": "Paper + Binders"}}, ExportPNG,
+			"?vf_Sub%20Category=Paper%20%2B%20Binders"},
+		{"high resolution png", ExportOptions{HighResolution: true}, ExportPNG, "?resolution=high"},
+		{"page layout of pdf", ExportOptions{PageType: "A4", Orientation: "Landscape"}, ExportPDF, "?orientation=Landscape&type=A4"},
+		{"png ignores page layout", ExportOptions{PageType: "A4", Orientation: "Landscape"}, ExportPNG, ""},
+		{"pdf ignores resolution", ExportOptions{HighResolution: true, MaxAge: 1}, ExportPDF, "?maxAge=1"},
+		{"csv ignores resolution and page layout", ExportOptions{HighResolution: true, PageType: "Letter"}, ExportCSV, ""},
+	}
+	for _, test := range tests {
+		if query := test.options.query(test.format); query != test.query {
+			t.Errorf("%s: query(%s) = %q, expecting %q", test.name, test.format, query, test.query)
+		}
+	}
+}
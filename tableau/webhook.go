@@ -0,0 +1,201 @@
+package tableau
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+// WebhookEvent is an event of a tableau site that triggers a webhook, as named in the payload tableau sends
+type WebhookEvent string
+
+// webhook events
+const (
+	EventDatasourceRefreshStarted   WebhookEvent = "DatasourceRefreshStarted"
+	EventDatasourceRefreshSucceeded WebhookEvent = "DatasourceRefreshSucceeded"
+	EventDatasourceRefreshFailed    WebhookEvent = "DatasourceRefreshFailed"
+	EventDatasourceUpdated          WebhookEvent = "DatasourceUpdated"
+	EventDatasourceCreated          WebhookEvent = "DatasourceCreated"
+	EventDatasourceDeleted          WebhookEvent = "DatasourceDeleted"
+	EventWorkbookUpdated            WebhookEvent = "WorkbookUpdated"
+	EventWorkbookCreated            WebhookEvent = "WorkbookCreated"
+	EventWorkbookDeleted            WebhookEvent = "WorkbookDeleted"
+	EventViewDeleted                WebhookEvent = "ViewDeleted"
+	EventWorkbookRefreshStarted     WebhookEvent = "WorkbookRefreshStarted"
+	EventWorkbookRefreshSucceeded   WebhookEvent = "WorkbookRefreshSucceeded"
+	EventWorkbookRefreshFailed      WebhookEvent = "WorkbookRefreshFailed"
+)
+
+// WebhookEvents are all events a webhook can be created for
+var WebhookEvents = []WebhookEvent{
+	EventDatasourceRefreshStarted, EventDatasourceRefreshSucceeded, EventDatasourceRefreshFailed,
+	EventDatasourceUpdated, EventDatasourceCreated, EventDatasourceDeleted,
+	EventWorkbookUpdated, EventWorkbookCreated, EventWorkbookDeleted, EventViewDeleted,
+	EventWorkbookRefreshStarted, EventWorkbookRefreshSucceeded, EventWorkbookRefreshFailed,
+}
+
+// webhookSourceElementPrefix prefixes the webhook-source element of an event, cfr sourceElement
+const webhookSourceElementPrefix = "webhook-source-event-"
+
+// sourceElement returns the name of the webhook-source element of an event,
+// e.g. webhook-source-event-workbook-refresh-failed for WorkbookRefreshFailed
+func (event WebhookEvent) sourceElement() string {
+	var element strings.Builder
+	element.WriteString(webhookSourceElementPrefix)
+	for i, r := range string(event) {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				element.WriteRune('-')
+			}
+			r = unicode.ToLower(r)
+		}
+		element.WriteRune(r)
+	}
+	return element.String()
+}
+
+// Webhook is a webhook of the current site with the event that triggers it
+type Webhook struct {
+	WebhookType
+	Event WebhookEvent
+}
+
+// webhookList decodes the event of listed webhooks, WebhookSourceType can not tell which of its (empty) elements is present
+type webhookList struct {
+	Webhooks []struct {
+		Id     ResourceIdType `xml:"id,attr"`
+		Source struct {
+			Events []struct {
+				XMLName xml.Name
+			} `xml:",any"`
+		} `xml:"webhook-source"`
+	} `xml:"webhooks>webhook"`
+}
+
+// ListWebhooks returns the webhooks of the current site
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_webhooks.htm#list_webhooks_for_site
+func (tabl *TabGo) ListWebhooks() ([]Webhook, error) {
+	webhooks := []Webhook{}
+	body, err := tabl.doRequest("GET", fmt.Sprintf("%s/webhooks", tabl.SiteURL()), "")
+	if err != nil {
+		return webhooks, errors.Wrapf(err, "can not list webhooks")
+	}
+	tsResponse := TsResponse{}
+	list := webhookList{}
+	if err = xml.Unmarshal(body, &tsResponse); err == nil {
+		err = xml.Unmarshal(body, &list)
+	}
+	if err != nil {
+		return webhooks, errors.Wrapf(err, "can not xml unmarshall webhooks '%s'", string(body))
+	}
+
+	events := make(map[ResourceIdType]WebhookEvent)
+	for _, webhook := range list.Webhooks {
+		for _, element := range webhook.Source.Events {
+			for _, event := range WebhookEvents {
+				if element.XMLName.Local == event.sourceElement() {
+					events[webhook.Id] = event
+				}
+			}
+		}
+	}
+	for _, webhook := range tsResponse.Webhooks.Webhook {
+		webhooks = append(webhooks, Webhook{WebhookType: webhook, Event: events[webhook.Id]})
+	}
+	return webhooks, nil
+}
+
+// GetWebhookByName returns the webhook with the given name
+func (tabl *TabGo) GetWebhookByName(name string) (Webhook, error) {
+	webhooks, err := tabl.ListWebhooks()
+	if err != nil {
+		return Webhook{}, err
+	}
+	for _, webhook := range webhooks {
+		if webhook.Name == name {
+			return webhook, nil
+		}
+	}
+	return Webhook{}, fmt.Errorf("no webhook '%s' found on site '%s'", name, tabl.CurrentSiteName)
+}
+
+// CreateWebhook creates a webhook that posts the payload of an event to url, tableau only accepts https urls
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_webhooks.htm#create_webhook
+func (tabl *TabGo) CreateWebhook(name string, event WebhookEvent, url string) (WebhookType, error) {
+	tsResponse, err := tabl.doTsRequest("POST", fmt.Sprintf("%s/webhooks", tabl.SiteURL()),
+		fmt.Sprintf(`<tsRequest><webhook name="%s"><webhook-source><%s /></webhook-source>`+
+			`<webhook-destination><webhook-destination-http method="POST" url="%s" /></webhook-destination></webhook></tsRequest>`,
+			xmlEscape(name), event.sourceElement(), xmlEscape(url)))
+	if err != nil {
+		return tsResponse.Webhook, errors.Wrapf(err, "can not create webhook '%s'", name)
+	}
+	return tsResponse.Webhook, nil
+}
+
+// TestWebhook makes tableau send a test payload to the destination of a webhook and returns the response of the destination
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_webhooks.htm#test_webhook
+func (tabl *TabGo) TestWebhook(webhookID string) (WebhookTestResultType, error) {
+	tsResponse, err := tabl.doTsRequest("GET", fmt.Sprintf("%s/webhooks/%s/test", tabl.SiteURL(), webhookID), "")
+	if err != nil {
+		return tsResponse.WebhookTestResult, errors.Wrapf(err, "can not test webhook '%s'", webhookID)
+	}
+	return tsResponse.WebhookTestResult, nil
+}
+
+// DeleteWebhook deletes a webhook
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_webhooks.htm#delete_webhook
+func (tabl *TabGo) DeleteWebhook(webhookID string) error {
+	_, err := tabl.doRequest("DELETE", fmt.Sprintf("%s/webhooks/%s", tabl.SiteURL(), webhookID), "")
+	if err != nil {
+		return errors.Wrapf(err, "can not delete webhook '%s'", webhookID)
+	}
+	return nil
+}
+
+// WebhookPayload is the json tableau posts to the destination of a webhook when its event occurs
+type WebhookPayload struct {
+	// Resource is the kind of content of the event: WORKBOOK, DATASOURCE or VIEW
+	Resource     string       `json:"resource"`
+	EventType    WebhookEvent `json:"event_type"`
+	ResourceName string       `json:"resource_name"`
+	ResourceID   string       `json:"resource_luid"`
+	SiteID       string       `json:"site_luid"`
+	CreatedAt    time.Time    `json:"created_at"`
+}
+
+func (payload WebhookPayload) String() string {
+	return fmt.Sprintf("%s %s %s (%s)", payload.CreatedAt.Format(time.RFC3339), payload.EventType, payload.ResourceName, payload.ResourceID)
+}
+
+// WebhookHandler returns an http handler receiving webhook payloads, handle is called with every decoded payload.
+// Tableau gets a 400 response for a payload that can not be decoded and a 500 response when handle fails.
+func WebhookHandler(handle func(WebhookPayload) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "expecting POST", http.StatusMethodNotAllowed)
+			return
+		}
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "can not read body", http.StatusBadRequest)
+			return
+		}
+		payload := WebhookPayload{}
+		if err = json.Unmarshal(body, &payload); err != nil {
+			http.Error(w, fmt.Sprintf("can not json decode payload: %v", err), http.StatusBadRequest)
+			return
+		}
+		if err = handle(payload); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+}
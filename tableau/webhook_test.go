@@ -0,0 +1,116 @@
+package tableau
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWebhookEventSourceElement(t *testing.T) {
+	tests := []struct {
+		event   WebhookEvent
+		element string
+	}{
+		{EventWorkbookRefreshFailed, "webhook-source-event-workbook-refresh-failed"},
+		{EventDatasourceCreated, "webhook-source-event-datasource-created"},
+		{EventViewDeleted, "webhook-source-event-view-deleted"},
+	}
+	for _, test := range tests {
+		if element := test.event.sourceElement(); element != test.element {
+			t.Errorf("%s.sourceElement() = %q, expecting %q", test.event, element, test.element)
+		}
+	}
+
+	elements := make(map[string]WebhookEvent)
+	for _, event := range WebhookEvents {
+		if other, found := elements[event.sourceElement()]; found {
+			t.Errorf("%s and %s have the same source element %s", event, other, event.sourceElement())
+		}
+		elements[event.sourceElement()] = event
+	}
+}
+
+func TestListWebhooks(t *testing.T) {
+	tabl, cleanup := newTestServer(t, map[string]string{
+		"/webhooks": `<webhooks>
+			<webhook id="w1" name="refresh failures">
+				<webhook-source><webhook-source-event-workbook-refresh-failed /></webhook-source>
+				<webhook-destination><webhook-destination-http method="POST" url="https://example.com/hook" /></webhook-destination>
+			</webhook>
+			<webhook id="w2" name="unknown">
+				<webhook-source><webhook-source-event-something-new /></webhook-source>
+			</webhook>
+		</webhooks>`,
+	})
+	defer cleanup()
+
+	webhooks, err := tabl.ListWebhooks()
+	if err != nil {
+		t.Fatal(err)
+	}
+	events := make(map[string]WebhookEvent)
+	for _, webhook := range webhooks {
+		events[webhook.Name] = webhook.Event
+	}
+	expected := map[string]WebhookEvent{"refresh failures": EventWorkbookRefreshFailed, "unknown": ""}
+	if !reflect.DeepEqual(events, expected) {
+		t.Errorf("events = %v, expecting %v", events, expected)
+	}
+}
+
+func TestWebhookHandler(t *testing.T) {
+	received := []WebhookPayload{}
+	server := httptest.NewServer(WebhookHandler(func(payload WebhookPayload) error {
+		if payload.ResourceName == "fail" {
+			return fmt.Errorf("can not handle %s", payload.ResourceName)
+		}
+		received = append(received, payload)
+		return nil
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name   string
+		method string
+		body   string
+		status int
+	}{
+		{"payload", "POST", `{"resource":"WORKBOOK","event_type":"WorkbookRefreshFailed","resource_name":"Revenue",` +
+			`"resource_luid":"w1","site_luid":"s1","created_at":"2020-03-01T10:00:00Z","unknown":true}`, http.StatusOK},
+		{"not a post", "GET", "", http.StatusMethodNotAllowed},
+		{"invalid json", "POST", `{"resource":`, http.StatusBadRequest},
+		{"handler fails", "POST", `{"resource_name":"fail"}`, http.StatusInternalServerError},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req, err := http.NewRequest(test.method, server.URL, strings.NewReader(test.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != test.status {
+				t.Errorf("status = %d, expecting %d", resp.StatusCode, test.status)
+			}
+		})
+	}
+
+	expected := []WebhookPayload{{
+		Resource:     "WORKBOOK",
+		EventType:    EventWorkbookRefreshFailed,
+		ResourceName: "Revenue",
+		ResourceID:   "w1",
+		SiteID:       "s1",
+		CreatedAt:    time.Date(2020, 3, 1, 10, 0, 0, 0, time.UTC),
+	}}
+	if !reflect.DeepEqual(received, expected) {
+		t.Errorf("received %+v, expecting %+v", received, expected)
+	}
+}
@@ -0,0 +1,64 @@
+package tableau
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// ConnectionUpdate changes the settings of a connection of a workbook, nil fields are left alone
+type ConnectionUpdate struct {
+	ServerAddress *string
+	ServerPort    *int
+	UserName      *string
+	Password      *string
+	EmbedPassword *bool
+}
+
+// payload returns the tsRequest updating a connection
+func (update ConnectionUpdate) payload() string {
+	attributes := ""
+	if update.ServerAddress != nil {
+		attributes += fmt.Sprintf(` serverAddress="%s"`, xmlEscape(*update.ServerAddress))
+	}
+	if update.ServerPort != nil {
+		attributes += fmt.Sprintf(` serverPort="%d"`, *update.ServerPort)
+	}
+	if update.UserName != nil {
+		attributes += fmt.Sprintf(` userName="%s"`, xmlEscape(*update.UserName))
+	}
+	if update.Password != nil {
+		attributes += fmt.Sprintf(` password="%s"`, xmlEscape(*update.Password))
+	}
+	if update.EmbedPassword != nil {
+		attributes += fmt.Sprintf(` embedPassword="%t"`, *update.EmbedPassword)
+	}
+	return fmt.Sprintf(`<tsRequest><connection%s /></tsRequest>`, attributes)
+}
+
+// IsPublishedDatasourceConnection tells whether a connection of a workbook connects to a published datasource
+// (type sqlproxy), its server and credentials are those of the datasource and are not updated through the workbook
+func IsPublishedDatasourceConnection(connection ConnectionType) bool {
+	return connection.Type == "sqlproxy"
+}
+
+// ListWorkbookConnections returns the connections of the datasources embedded in a workbook
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_workbooksviews.htm#query_workbook_connections
+func (tabl *TabGo) ListWorkbookConnections(workbookID string) ([]ConnectionType, error) {
+	tsResponse, err := tabl.doTsRequest("GET", fmt.Sprintf("%s/workbooks/%s/connections", tabl.SiteURL(), workbookID), "")
+	if err != nil {
+		return tsResponse.Connections.Connection, errors.Wrapf(err, "can not list connections of workbook '%s'", workbookID)
+	}
+	return tsResponse.Connections.Connection, nil
+}
+
+// UpdateWorkbookConnection changes the server address, port, credentials and/or embed flag of a connection of a workbook,
+// e.g. to rotate the credentials of its embedded datasources without republishing it
+// cfr https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_workbooksviews.htm#update_workbook_connection
+func (tabl *TabGo) UpdateWorkbookConnection(workbookID, connectionID string, update ConnectionUpdate) (ConnectionType, error) {
+	tsResponse, err := tabl.doTsRequest("PUT", fmt.Sprintf("%s/workbooks/%s/connections/%s", tabl.SiteURL(), workbookID, connectionID), update.payload())
+	if err != nil {
+		return tsResponse.Connection, errors.Wrapf(err, "can not update connection '%s' of workbook '%s'", connectionID, workbookID)
+	}
+	return tsResponse.Connection, nil
+}
@@ -0,0 +1,36 @@
+package tableau
+
+import "testing"
+
+func TestConnectionUpdatePayload(t *testing.T) {
+	address, user, password, empty := "dwh.example.com", "reader", `p"w<d`, ""
+	port := 5432
+	embed := false
+
+	tests := []struct {
+		name    string
+		update  ConnectionUpdate
+		payload string
+	}{
+		{"nothing", ConnectionUpdate{}, `<tsRequest><connection /></tsRequest>`},
+		{"server", ConnectionUpdate{ServerAddress: &address, ServerPort: &port},
+			`<tsRequest><connection serverAddress="dwh.example.com" serverPort="5432" /></tsRequest>`},
+		{"escaped credentials", ConnectionUpdate{UserName: &user, Password: &password},
+			`<tsRequest><connection userName="reader" password="p&#34;w&lt;d" /></tsRequest>`},
+		{"cleared password, not embedded", ConnectionUpdate{Password: &empty, EmbedPassword: &embed},
+			`<tsRequest><connection password="" embedPassword="false" /></tsRequest>`},
+	}
+	for _, test := range tests {
+		if payload := test.update.payload(); payload != test.payload {
+			t.Errorf("%s: payload = %s, expecting %s", test.name, payload, test.payload)
+		}
+	}
+}
+
+func TestIsPublishedDatasourceConnection(t *testing.T) {
+	for connectionType, published := range map[string]bool{"sqlproxy": true, "postgres": false, "": false} {
+		if IsPublishedDatasourceConnection(ConnectionType{Type: connectionType}) != published {
+			t.Errorf("IsPublishedDatasourceConnection(%q) != %t", connectionType, published)
+		}
+	}
+}